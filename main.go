@@ -1,17 +1,41 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
+	"strings"
+	"text/tabwriter"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/chloebubble/tuv/pkg/config"
+	"github.com/chloebubble/tuv/pkg/sbom"
 	"github.com/chloebubble/tuv/pkg/ui"
 )
 
 func main() {
+	configPath := flag.String("config", "", "config file to use instead of $TUV_CONFIG or the default location")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: tuv [--config FILE] [config show | sbom [--format cyclonedx|spdx] [--output FILE] [DIR]]\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	switch args := flag.Args(); {
+	case len(args) == 0:
+	case strings.Join(args, " ") == "config show":
+		os.Exit(showConfig(*configPath))
+	case args[0] == "sbom":
+		// Building an SBOM doesn't need the TUI's config, so a broken one mustn't stop it
+		os.Exit(sbomCommand(args[1:]))
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command: %s\n\n", strings.Join(args, " "))
+		flag.Usage()
+		os.Exit(2)
+	}
+
 	// Load configuration
-	cfg, err := config.LoadConfig()
+	cfg, err := config.LoadConfig(*configPath)
 	if err != nil {
 		fmt.Printf("Error loading configuration: %v\n", err)
 		os.Exit(1)
@@ -26,3 +50,88 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// showConfig prints the effective configuration, where each value came from and any problems
+// with it, returning the exit code. It works on a config tuv would refuse to start with, so the
+// values can be checked against the errors.
+func showConfig(path string) int {
+	cfg, err := config.InspectConfig(path)
+	if cfg == nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		return 1
+	}
+
+	file := cfg.ConfigFileLocation
+	if cfg.IsFirstRun() {
+		file += " (not created yet)"
+	}
+	fmt.Printf("Config file: %s\n", file)
+	fmt.Printf("Data dir:    %s\n", config.DataDir())
+	fmt.Printf("Cache dir:   %s\n\n", config.CacheDir())
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tVALUE\tSOURCE")
+	for _, s := range cfg.Effective() {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", s.Key, s.Value, s.Source)
+	}
+	w.Flush()
+
+	if warnings := cfg.Warnings(); len(warnings) > 0 {
+		fmt.Println()
+		for _, warning := range warnings {
+			fmt.Printf("Warning: %s\n", warning)
+		}
+	}
+	if err != nil {
+		fmt.Printf("\nErrors:\n%v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// sbomCommand writes a project's SBOM to stdout or a file and returns the exit code
+func sbomCommand(args []string) int {
+	flags := flag.NewFlagSet("sbom", flag.ContinueOnError)
+	formatName := flags.String("format", string(sbom.CycloneDX), "SBOM format, cyclonedx or spdx")
+	output := flags.String("output", "", "file to write the SBOM to instead of stdout")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: tuv sbom [--format cyclonedx|spdx] [--output FILE] [DIR]\n\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() > 1 {
+		flags.Usage()
+		return 2
+	}
+	format, err := sbom.ParseFormat(*formatName)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	dir := "."
+	if flags.NArg() == 1 {
+		dir = flags.Arg(0)
+	}
+	bom, err := sbom.Build(dir, "")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error building SBOM: %v\n", err)
+		return 1
+	}
+
+	w := os.Stdout
+	if *output != "" {
+		if w, err = os.Create(*output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing SBOM: %v\n", err)
+			return 1
+		}
+		defer w.Close()
+	}
+	if err := bom.Write(w, format); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing SBOM: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,361 @@
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Entry describes an archived project, stored as JSON next to its tarball
+type Entry struct {
+	Name          string    `json:"name"`
+	OriginalPath  string    `json:"original_path"`
+	ArchivedAt    time.Time `json:"archived_at"`
+	PythonVersion string    `json:"python_version"`
+	OriginalSize  int64     `json:"original_size"`
+	ArchiveSize   int64     `json:"archive_size"`
+	Excluded      []string  `json:"excluded"`
+	File          string    `json:"file"`
+}
+
+// Path returns the full path to the entry's tarball
+func (e Entry) Path(archiveDir string) string {
+	return filepath.Join(archiveDir, e.File)
+}
+
+// metadataPath returns the full path to the entry's metadata file
+func (e Entry) metadataPath(archiveDir string) string {
+	return filepath.Join(archiveDir, strings.TrimSuffix(e.File, ".tar.gz")+".json")
+}
+
+// checkFile makes sure the entry's tarball is a file directly in the archive directory. The file
+// name comes from a metadata file anyone could have edited, and Restore and Delete remove it.
+func (e Entry) checkFile() error {
+	base := strings.TrimSuffix(e.File, ".tar.gz")
+	if base == "" || base == e.File || base == "." || base == ".." || strings.ContainsAny(e.File, `/\`) {
+		return fmt.Errorf("archive file %q is not a .tar.gz in the archive directory", e.File)
+	}
+	return nil
+}
+
+// Create packs a project into a .tar.gz in archiveDir, skipping directories named in excludes,
+// and writes its metadata. The original project is left in place.
+func Create(projectPath, archiveDir string, excludes []string, pythonVersion string, originalSize int64) (Entry, error) {
+	if err := os.MkdirAll(archiveDir, 0755); err != nil {
+		return Entry{}, err
+	}
+
+	name := filepath.Base(projectPath)
+	now := time.Now()
+	entry := Entry{
+		Name:          name,
+		OriginalPath:  projectPath,
+		ArchivedAt:    now,
+		PythonVersion: pythonVersion,
+		OriginalSize:  originalSize,
+		Excluded:      excludes,
+		File:          fmt.Sprintf("%s-%s.tar.gz", name, now.Format("20060102-150405")),
+	}
+
+	archivePath := entry.Path(archiveDir)
+	if err := writeTarball(projectPath, archivePath, excludes); err != nil {
+		os.Remove(archivePath)
+		return Entry{}, err
+	}
+
+	if info, err := os.Stat(archivePath); err == nil {
+		entry.ArchiveSize = info.Size()
+	}
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return Entry{}, err
+	}
+	if err := os.WriteFile(entry.metadataPath(archiveDir), data, 0644); err != nil {
+		os.Remove(archivePath)
+		return Entry{}, err
+	}
+
+	return entry, nil
+}
+
+// writeTarball writes the contents of projectPath into a gzip-compressed tarball rooted at the project's name
+func writeTarball(projectPath, archivePath string, excludes []string) error {
+	file, err := os.Create(archivePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gz := gzip.NewWriter(file)
+	tw := tar.NewWriter(gz)
+	root := filepath.Base(projectPath)
+
+	err = filepath.WalkDir(projectPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && path != projectPath && slices.Contains(excludes, d.Name()) {
+			return filepath.SkipDir
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		var link string
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(path); err != nil {
+				return err
+			}
+		}
+
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(projectPath, path)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(filepath.Join(root, rel))
+		if d.IsDir() {
+			header.Name += "/"
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// List returns the archived projects in archiveDir, newest first
+func List(archiveDir string) ([]Entry, error) {
+	matches, err := filepath.Glob(filepath.Join(archiveDir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, path := range matches {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(data, &entry); err != nil || entry.checkFile() != nil {
+			continue
+		}
+		// Each metadata file describes the tarball of the same name, so it can't claim another's
+		if entry.metadataPath(archiveDir) != path {
+			continue
+		}
+		if _, err := os.Stat(entry.Path(archiveDir)); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ArchivedAt.After(entries[j].ArchivedAt)
+	})
+	return entries, nil
+}
+
+// Restore unpacks an archive into parentDir and returns the restored project path.
+// The archive and its metadata are removed once extraction succeeds.
+func Restore(entry Entry, archiveDir, parentDir string) (string, error) {
+	if err := entry.checkFile(); err != nil {
+		return "", err
+	}
+	// The name comes from a metadata file anyone could have edited, so it must be a single directory name
+	if entry.Name == "" || entry.Name == "." || entry.Name == ".." || strings.ContainsAny(entry.Name, `/\`) {
+		return "", fmt.Errorf("archive name %q is not a directory name", entry.Name)
+	}
+	target := filepath.Join(parentDir, entry.Name)
+	if _, err := os.Lstat(target); err == nil {
+		return "", fmt.Errorf("%s already exists", target)
+	}
+
+	if err := extractTarball(entry.Path(archiveDir), target); err != nil {
+		os.RemoveAll(target)
+		return "", err
+	}
+
+	if err := Delete(entry, archiveDir); err != nil {
+		return target, err
+	}
+	return target, nil
+}
+
+// Delete removes an archive and its metadata
+func Delete(entry Entry, archiveDir string) error {
+	if err := entry.checkFile(); err != nil {
+		return err
+	}
+	if err := os.Remove(entry.Path(archiveDir)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.Remove(entry.metadataPath(archiveDir)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// extractTarball unpacks a gzip-compressed tarball rooted at the base name of target into
+// target. Entries outside target, entries written through a symlink and links pointing outside
+// target are rejected, so a crafted archive can't write or expose anything else.
+func extractTarball(archivePath, target string) error {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	parent := filepath.Dir(target)
+	var links []string
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		path := filepath.Join(parent, filepath.FromSlash(header.Name))
+		if !within(target, path) || (path == target && header.Typeflag != tar.TypeDir) || throughSymlink(target, path) {
+			return fmt.Errorf("archive entry %q is outside the destination", header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, os.FileMode(header.Mode)|0700); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			linked := filepath.Join(filepath.Dir(path), filepath.FromSlash(header.Linkname))
+			if filepath.IsAbs(header.Linkname) || !within(target, linked) {
+				return fmt.Errorf("archive entry %q links to %q, outside the destination", header.Name, header.Linkname)
+			}
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return err
+			}
+			if err := os.Symlink(header.Linkname, path); err != nil {
+				return err
+			}
+			links = append(links, path)
+		case tar.TypeLink:
+			// Hard link names are relative to the archive root, like entry names
+			linked := filepath.Join(parent, filepath.FromSlash(header.Linkname))
+			if !within(target, linked) || throughSymlink(target, linked) {
+				return fmt.Errorf("archive entry %q links to %q, outside the destination", header.Name, header.Linkname)
+			}
+			if info, err := os.Lstat(linked); err != nil || !info.Mode().IsRegular() {
+				return fmt.Errorf("archive entry %q links to %q, which is not a file in the archive", header.Name, header.Linkname)
+			}
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return err
+			}
+			if err := os.Link(linked, path); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode))
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(f, tr); err != nil {
+				f.Close()
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+			os.Chtimes(path, header.ModTime, header.ModTime)
+		}
+	}
+
+	// A symlink's target can itself go through other symlinks, so check where each one really
+	// ends up now that they all exist
+	realTarget, err := filepath.EvalSymlinks(target)
+	if err != nil {
+		return err
+	}
+	for _, link := range links {
+		if real, err := filepath.EvalSymlinks(link); err == nil && !within(realTarget, real) {
+			rel, _ := filepath.Rel(parent, link)
+			return fmt.Errorf("archive entry %q links outside the destination", filepath.ToSlash(rel))
+		}
+	}
+	return nil
+}
+
+// within reports whether path is dir or inside it
+func within(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// throughSymlink reports whether path, or a directory between dir and it, is an existing symlink.
+// Archives are written without following symlinks, so no entry legitimately goes through one.
+func throughSymlink(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil || rel == "." {
+		return false
+	}
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		dir = filepath.Join(dir, part)
+		info, err := os.Lstat(dir)
+		if err != nil {
+			// Nothing exists below a missing directory either
+			return false
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,266 @@
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// tarEntry is one entry of a tarball built by writeTestTarball
+type tarEntry struct {
+	name     string
+	typeflag byte
+	linkname string
+	body     string
+}
+
+// writeTestTarball writes entries into a gzip-compressed tarball in dir and returns its path
+func writeTestTarball(t *testing.T, dir string, entries []tarEntry) string {
+	t.Helper()
+	path := filepath.Join(dir, "crafted.tar.gz")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	gz := gzip.NewWriter(file)
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		header := &tar.Header{Name: e.name, Typeflag: e.typeflag, Linkname: e.linkname, Mode: 0644, Size: int64(len(e.body))}
+		if e.typeflag == tar.TypeDir {
+			header.Mode = 0755
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(e.body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestExtractTarballRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []tarEntry
+	}{
+		{
+			name:    "parent directory entry",
+			entries: []tarEntry{{name: "proj/../escaped.txt", typeflag: tar.TypeReg, body: "x"}},
+		},
+		{
+			name:    "sibling directory entry",
+			entries: []tarEntry{{name: "../../escaped.txt", typeflag: tar.TypeReg, body: "x"}},
+		},
+		{
+			name:    "file replacing the project directory",
+			entries: []tarEntry{{name: "proj", typeflag: tar.TypeReg, body: "x"}},
+		},
+		{
+			name:    "absolute symlink",
+			entries: []tarEntry{{name: "proj/etc", typeflag: tar.TypeSymlink, linkname: "/etc"}},
+		},
+		{
+			name:    "relative symlink out of the project",
+			entries: []tarEntry{{name: "proj/up", typeflag: tar.TypeSymlink, linkname: "../outside"}},
+		},
+		{
+			// Each link stays inside lexically, but esc resolves through sub/up to the parent
+			name: "symlink chain out of the project",
+			entries: []tarEntry{
+				{name: "proj/sub/", typeflag: tar.TypeDir},
+				{name: "proj/sub/up", typeflag: tar.TypeSymlink, linkname: ".."},
+				{name: "proj/esc", typeflag: tar.TypeSymlink, linkname: "sub/up/.."},
+			},
+		},
+		{
+			name:    "hard link to an outside file",
+			entries: []tarEntry{{name: "proj/secret", typeflag: tar.TypeLink, linkname: "outside.txt"}},
+		},
+		{
+			name: "hard link through a symlink",
+			entries: []tarEntry{
+				{name: "proj/out", typeflag: tar.TypeSymlink, linkname: "."},
+				{name: "proj/secret", typeflag: tar.TypeLink, linkname: "proj/out/file"},
+			},
+		},
+		{
+			name: "entry written through a symlinked directory",
+			entries: []tarEntry{
+				{name: "proj/sub/", typeflag: tar.TypeDir},
+				{name: "proj/link", typeflag: tar.TypeSymlink, linkname: "sub"},
+				{name: "proj/link/file.txt", typeflag: tar.TypeReg, body: "x"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			parent := filepath.Join(dir, "parent")
+			if err := os.Mkdir(parent, 0755); err != nil {
+				t.Fatal(err)
+			}
+			outside := filepath.Join(parent, "outside.txt")
+			if err := os.WriteFile(outside, []byte("secret"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			archivePath := writeTestTarball(t, dir, tt.entries)
+			if err := extractTarball(archivePath, filepath.Join(parent, "proj")); err == nil {
+				t.Fatal("extractTarball accepted a crafted archive")
+			}
+			if _, err := os.Lstat(filepath.Join(parent, "escaped.txt")); err == nil {
+				t.Error("an entry was written outside the project")
+			}
+			if _, err := os.Lstat(filepath.Join(dir, "escaped.txt")); err == nil {
+				t.Error("an entry was written outside the project")
+			}
+			if data, err := os.ReadFile(outside); err != nil || string(data) != "secret" {
+				t.Errorf("outside file changed: %q, %v", data, err)
+			}
+		})
+	}
+}
+
+func TestCreateRestore(t *testing.T) {
+	dir := t.TempDir()
+	projectPath := filepath.Join(dir, "projects", "demo")
+	files := map[string]string{
+		"pyproject.toml":       "[project]\nname = \"demo\"\n",
+		"src/demo/__init__.py": "print('hi')\n",
+		".venv/bin/python":     "not copied",
+	}
+	for name, body := range files {
+		path := filepath.Join(projectPath, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink("src/demo", filepath.Join(projectPath, "pkg")); err != nil {
+		t.Fatal(err)
+	}
+
+	archiveDir := filepath.Join(dir, "archives")
+	entry, err := Create(projectPath, archiveDir, []string{".venv"}, "3.12", 0)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	entries, err := List(archiveDir)
+	if err != nil || len(entries) != 1 || entries[0].File != entry.File {
+		t.Fatalf("List = %v, %v, want the created entry", entries, err)
+	}
+
+	restoreDir := filepath.Join(dir, "restored")
+	if err := os.Mkdir(restoreDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	target, err := Restore(entries[0], archiveDir, restoreDir)
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if target != filepath.Join(restoreDir, "demo") {
+		t.Errorf("Restore returned %s", target)
+	}
+
+	for name, body := range files {
+		data, err := os.ReadFile(filepath.Join(target, filepath.FromSlash(name)))
+		if strings.HasPrefix(name, ".venv/") {
+			if err == nil {
+				t.Errorf("%s was archived although .venv is excluded", name)
+			}
+			continue
+		}
+		if err != nil || string(data) != body {
+			t.Errorf("%s = %q, %v, want %q", name, data, err, body)
+		}
+	}
+	if link, err := os.Readlink(filepath.Join(target, "pkg")); err != nil || link != "src/demo" {
+		t.Errorf("pkg symlink = %q, %v, want src/demo", link, err)
+	}
+
+	if remaining, _ := os.ReadDir(archiveDir); len(remaining) != 0 {
+		t.Errorf("archive files left after restore: %v", remaining)
+	}
+}
+
+func TestEntryFileOutsideArchiveDir(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+	}{
+		{name: "parent directory", file: "../victim.tar.gz"},
+		{name: "absolute path", file: "/tmp/victim.tar.gz"},
+		{name: "subdirectory", file: "sub/victim.tar.gz"},
+		{name: "not a tarball", file: "victim.txt"},
+		{name: "empty", file: ""},
+		{name: "only the extension", file: ".tar.gz"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			archiveDir := filepath.Join(dir, "archives")
+			if err := os.MkdirAll(filepath.Join(archiveDir, "sub"), 0755); err != nil {
+				t.Fatal(err)
+			}
+			victim := filepath.Join(dir, "victim.tar.gz")
+			for _, path := range []string{victim, filepath.Join(archiveDir, "sub", "victim.tar.gz")} {
+				if err := os.WriteFile(path, []byte("keep"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			metadata := filepath.Join(archiveDir, "evil.json")
+			if err := os.WriteFile(metadata, []byte(`{"name": "evil", "file": "`+tt.file+`"}`), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			if entries, err := List(archiveDir); err != nil || len(entries) != 0 {
+				t.Errorf("List = %v, %v, want no entries", entries, err)
+			}
+			entry := Entry{Name: "evil", File: tt.file}
+			if err := Delete(entry, archiveDir); err == nil {
+				t.Error("Delete accepted the entry")
+			}
+			if _, err := Restore(entry, archiveDir, dir); err == nil {
+				t.Error("Restore accepted the entry")
+			}
+			if _, err := os.Stat(victim); err != nil {
+				t.Errorf("file outside the archive directory was removed: %v", err)
+			}
+			if _, err := os.Stat(filepath.Join(archiveDir, "sub", "victim.tar.gz")); err != nil {
+				t.Errorf("file in a subdirectory was removed: %v", err)
+			}
+		})
+	}
+}
+
+func TestListSkipsMetadataForAnotherTarball(t *testing.T) {
+	archiveDir := t.TempDir()
+	files := map[string]string{
+		"a-20240101-000000.tar.gz": "",
+		"a-20240101-000000.json":   `{"name": "a", "file": "a-20240101-000000.tar.gz"}`,
+		"b-20240101-000000.json":   `{"name": "b", "file": "a-20240101-000000.tar.gz"}`,
+	}
+	for name, body := range files {
+		if err := os.WriteFile(filepath.Join(archiveDir, name), []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	entries, err := List(archiveDir)
+	if err != nil || len(entries) != 1 || entries[0].Name != "a" {
+		t.Errorf("List = %v, %v, want only a", entries, err)
+	}
+}
@@ -0,0 +1,129 @@
+package audit
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/chloebubble/tuv/pkg/scanner"
+)
+
+// Finding is an advisory that affects a package locked by a project
+type Finding struct {
+	Package string
+	Version string
+	Match
+}
+
+// Report is the audit result for one project
+type Report struct {
+	Project  scanner.UVProject
+	Packages int
+	Findings []Finding
+	// Skipped says why the project wasn't audited, e.g. it has no uv.lock
+	Skipped string
+}
+
+// Worst returns the highest severity among the report's findings
+func (r Report) Worst() Severity {
+	worst := SeverityUnknown
+	for _, f := range r.Findings {
+		worst = max(worst, f.Severity)
+	}
+	return worst
+}
+
+// Run audits every project against the database, stopping early if ctx is cancelled. progress,
+// if set, is called with each project. Workspace members share their root's lock, so only roots are audited.
+func Run(ctx context.Context, db *Database, projects []scanner.UVProject, progress func(done int, project scanner.UVProject)) ([]Report, error) {
+	var reports []Report
+	for i, project := range projects {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if project.IsWorkspaceMember() {
+			continue
+		}
+		if progress != nil {
+			progress(i, project)
+		}
+		reports = append(reports, AuditProject(db, project))
+	}
+	return reports, nil
+}
+
+// AuditProject matches the packages in a project's uv.lock against the database. Local packages,
+// such as the project itself and workspace members, are not audited.
+func AuditProject(db *Database, project scanner.UVProject) Report {
+	report := Report{Project: project}
+	lock, err := scanner.ReadLock(project.Path)
+	if err != nil {
+		report.Skipped = "no readable uv.lock"
+		return report
+	}
+
+	for _, pkg := range lock.Packages {
+		if pkg.IsLocal() || pkg.Version == "" {
+			continue
+		}
+		report.Packages++
+		for _, match := range db.Affecting(pkg.Name, pkg.Version) {
+			report.Findings = append(report.Findings, Finding{Package: pkg.Name, Version: pkg.Version, Match: match})
+		}
+	}
+	slices.SortStableFunc(report.Findings, func(a, b Finding) int {
+		return cmp.Or(cmp.Compare(b.Severity, a.Severity), cmp.Compare(a.Package, b.Package), cmp.Compare(a.Advisory.ID, b.Advisory.ID))
+	})
+	return report
+}
+
+// Summary is the cross-project view of an audit
+type Summary struct {
+	Audited  int
+	Affected int
+	Findings int
+	// BySeverity counts findings at each severity
+	BySeverity map[Severity]int
+	// Advisories lists each advisory found with the projects it affects, most widespread first
+	Advisories []AdvisoryUse
+}
+
+// AdvisoryUse is one advisory and the projects whose locks it affects
+type AdvisoryUse struct {
+	Finding
+	Projects []string
+}
+
+// Summarize totals findings across reports
+func Summarize(reports []Report) Summary {
+	summary := Summary{BySeverity: make(map[Severity]int)}
+	index := make(map[string]int)
+	for _, report := range reports {
+		if report.Skipped != "" {
+			continue
+		}
+		summary.Audited++
+		if len(report.Findings) > 0 {
+			summary.Affected++
+		}
+		for _, finding := range report.Findings {
+			summary.Findings++
+			summary.BySeverity[finding.Severity]++
+			key := finding.Advisory.ID + " " + finding.Package
+			i, ok := index[key]
+			if !ok {
+				i = len(summary.Advisories)
+				index[key] = i
+				summary.Advisories = append(summary.Advisories, AdvisoryUse{Finding: finding})
+			}
+			use := &summary.Advisories[i]
+			if !slices.Contains(use.Projects, report.Project.Name) {
+				use.Projects = append(use.Projects, report.Project.Name)
+			}
+		}
+	}
+	slices.SortStableFunc(summary.Advisories, func(a, b AdvisoryUse) int {
+		return cmp.Or(cmp.Compare(b.Severity, a.Severity), cmp.Compare(len(b.Projects), len(a.Projects)), cmp.Compare(a.Advisory.ID, b.Advisory.ID))
+	})
+	return summary
+}
@@ -0,0 +1,281 @@
+package audit
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/chloebubble/tuv/pkg/pyversion"
+	"github.com/chloebubble/tuv/pkg/scanner"
+)
+
+// Advisory is an OSV vulnerability record, reduced to the fields the audit uses
+type Advisory struct {
+	ID        string     `json:"id"`
+	Aliases   []string   `json:"aliases"`
+	Summary   string     `json:"summary"`
+	Withdrawn string     `json:"withdrawn"`
+	Severity  []Score    `json:"severity"`
+	Affected  []Affected `json:"affected"`
+	Database  struct {
+		Severity string `json:"severity"`
+	} `json:"database_specific"`
+}
+
+// Score is a severity score such as a CVSS vector
+type Score struct {
+	Type  string `json:"type"`
+	Score string `json:"score"`
+}
+
+// Affected describes the versions of one package an advisory applies to
+type Affected struct {
+	Package struct {
+		Ecosystem string `json:"ecosystem"`
+		Name      string `json:"name"`
+	} `json:"package"`
+	Ranges   []Range  `json:"ranges"`
+	Versions []string `json:"versions"`
+	Severity []Score  `json:"severity"`
+	Database struct {
+		Severity string `json:"severity"`
+	} `json:"database_specific"`
+}
+
+// Range is a span of affected versions, described by introduced, fixed and last_affected events
+type Range struct {
+	Type   string  `json:"type"`
+	Events []Event `json:"events"`
+}
+
+// Event is one boundary of a Range. Exactly one field is set.
+type Event struct {
+	Introduced   string `json:"introduced"`
+	Fixed        string `json:"fixed"`
+	LastAffected string `json:"last_affected"`
+}
+
+// Database is a local copy of OSV advisories for PyPI packages, indexed by package name
+type Database struct {
+	Dir        string
+	Advisories int
+	byPackage  map[string][]*Advisory
+}
+
+// LoadDatabase reads every OSV advisory under dir. Advisories may be loose .json files or the
+// .zip archives OSV publishes, such as PyPI/all.zip. Withdrawn advisories and other ecosystems are skipped.
+func LoadDatabase(ctx context.Context, dir string) (*Database, error) {
+	if info, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("advisory database %s: %w", dir, err)
+	} else if !info.IsDir() {
+		return nil, fmt.Errorf("advisory database %s is not a directory", dir)
+	}
+
+	db := &Database{Dir: dir, byPackage: make(map[string][]*Advisory)}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".json":
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			return db.add(path, data)
+		case ".zip":
+			return db.addZip(ctx, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if db.Advisories == 0 {
+		return nil, fmt.Errorf("no OSV advisories found in %s", dir)
+	}
+	return db, nil
+}
+
+// addZip reads every advisory in an OSV archive
+func (db *Database) addZip(ctx context.Context, path string) error {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	defer archive.Close()
+
+	for _, file := range archive.File {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !strings.EqualFold(filepath.Ext(file.Name), ".json") {
+			continue
+		}
+		r, err := file.Open()
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		data, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if err := db.add(path+":"+file.Name, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// add indexes one advisory under each PyPI package it affects
+func (db *Database) add(source string, data []byte) error {
+	var advisory Advisory
+	if err := json.Unmarshal(data, &advisory); err != nil {
+		return fmt.Errorf("%s: %w", source, err)
+	}
+	if advisory.ID == "" || advisory.Withdrawn != "" {
+		return nil
+	}
+
+	indexed := make(map[string]bool)
+	for _, affected := range advisory.Affected {
+		name := scanner.NormalizeName(affected.Package.Name)
+		if !isPyPI(affected.Package.Ecosystem) || indexed[name] {
+			continue
+		}
+		db.byPackage[name] = append(db.byPackage[name], &advisory)
+		indexed[name] = true
+	}
+	if len(indexed) > 0 {
+		db.Advisories++
+	}
+	return nil
+}
+
+// isPyPI reports whether an OSV ecosystem is PyPI, which may carry a suffix such as "PyPI:3.12"
+func isPyPI(ecosystem string) bool {
+	name, _, _ := strings.Cut(ecosystem, ":")
+	return name == "PyPI"
+}
+
+// Affecting returns the advisories that apply to a version of a package, with the versions that fix each
+func (db *Database) Affecting(name, version string) []Match {
+	v, err := pyversion.ParsePackageVersion(version)
+	if err != nil {
+		return nil
+	}
+	name = scanner.NormalizeName(name)
+
+	var matches []Match
+	for _, advisory := range db.byPackage[name] {
+		for _, affected := range advisory.Affected {
+			if !isPyPI(affected.Package.Ecosystem) || scanner.NormalizeName(affected.Package.Name) != name {
+				continue
+			}
+			if fixed, ok := affected.affects(version, v); ok {
+				matches = append(matches, Match{Advisory: advisory, Severity: severityOf(advisory, affected), Fixed: fixed})
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// Match is an advisory that applies to a locked package
+type Match struct {
+	Advisory *Advisory
+	Severity Severity
+	// Fixed lists the releases that fix the advisory, if any are known
+	Fixed []string
+}
+
+// errBadEvent marks a range event whose version can't be parsed
+var errBadEvent = errors.New("unparseable version in range")
+
+// affects reports whether the version falls in any of the affected ranges or explicit versions,
+// and returns the fixed versions of the ranges it falls in
+func (a Affected) affects(text string, v pyversion.PackageVersion) ([]string, bool) {
+	var fixed []string
+	hit := false
+	for _, r := range a.Ranges {
+		if r.Type != "ECOSYSTEM" && r.Type != "SEMVER" {
+			continue
+		}
+		inRange, fix, err := r.contains(v)
+		if err != nil || !inRange {
+			continue
+		}
+		hit = true
+		if fix != "" {
+			fixed = append(fixed, fix)
+		}
+	}
+	for _, listed := range a.Versions {
+		if listed == text {
+			hit = true
+			break
+		}
+		if lv, err := pyversion.ParsePackageVersion(listed); err == nil && lv.Compare(v) == 0 {
+			hit = true
+			break
+		}
+	}
+	return fixed, hit
+}
+
+// contains walks a range's events in the order OSV lists them, which pairs each introduced
+// event with the fixed or last_affected event after it. It returns the fix for the span v is in.
+func (r Range) contains(v pyversion.PackageVersion) (bool, string, error) {
+	compare := func(text string) (int, error) {
+		if text == "0" {
+			return 1, nil
+		}
+		bound, err := pyversion.ParsePackageVersion(text)
+		if err != nil {
+			return 0, errBadEvent
+		}
+		return v.Compare(bound), nil
+	}
+
+	introduced := false
+	for _, event := range r.Events {
+		switch {
+		case event.Introduced != "":
+			c, err := compare(event.Introduced)
+			if err != nil {
+				return false, "", err
+			}
+			introduced = c >= 0
+		case event.Fixed != "" && introduced:
+			c, err := compare(event.Fixed)
+			if err != nil {
+				return false, "", err
+			}
+			if c < 0 {
+				return true, event.Fixed, nil
+			}
+			introduced = false
+		case event.LastAffected != "" && introduced:
+			c, err := compare(event.LastAffected)
+			if err != nil {
+				return false, "", err
+			}
+			if c <= 0 {
+				return true, "", nil
+			}
+			introduced = false
+		}
+	}
+	return introduced, "", nil
+}
@@ -0,0 +1,63 @@
+package audit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/chloebubble/tuv/pkg/pyversion"
+)
+
+func TestRangeContains(t *testing.T) {
+	tests := []struct {
+		name    string
+		events  []Event
+		version string
+		want    bool
+		fix     string
+		wantErr bool
+	}{
+		{name: "from zero before fix", events: []Event{{Introduced: "0"}, {Fixed: "2.0"}}, version: "1.5", want: true, fix: "2.0"},
+		{name: "at fix", events: []Event{{Introduced: "0"}, {Fixed: "2.0"}}, version: "2.0", want: false},
+		{name: "pre-release of fix", events: []Event{{Introduced: "0"}, {Fixed: "2.0"}}, version: "2.0rc1", want: true, fix: "2.0"},
+		{name: "before introduced", events: []Event{{Introduced: "1.2"}, {Fixed: "1.4"}}, version: "1.1", want: false},
+		{name: "at introduced", events: []Event{{Introduced: "1.2"}, {Fixed: "1.4"}}, version: "1.2", want: true, fix: "1.4"},
+		{name: "no fix yet", events: []Event{{Introduced: "1.0"}}, version: "9.9", want: true},
+		{name: "at last affected", events: []Event{{Introduced: "1.0"}, {LastAffected: "1.3"}}, version: "1.3", want: true},
+		{name: "after last affected", events: []Event{{Introduced: "1.0"}, {LastAffected: "1.3"}}, version: "1.3.1", want: false},
+		{
+			name:    "second span",
+			events:  []Event{{Introduced: "1.0"}, {Fixed: "1.2"}, {Introduced: "2.0"}, {Fixed: "2.3"}},
+			version: "2.1",
+			want:    true,
+			fix:     "2.3",
+		},
+		{
+			name:    "between spans",
+			events:  []Event{{Introduced: "1.0"}, {Fixed: "1.2"}, {Introduced: "2.0"}, {Fixed: "2.3"}},
+			version: "1.5",
+			want:    false,
+		},
+		{name: "unparseable event", events: []Event{{Introduced: "0"}, {Fixed: "not a version"}}, version: "1.0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := pyversion.ParsePackageVersion(tt.version)
+			if err != nil {
+				t.Fatalf("ParsePackageVersion(%q): %v", tt.version, err)
+			}
+			got, fix, err := Range{Type: "ECOSYSTEM", Events: tt.events}.contains(v)
+			if tt.wantErr {
+				if !errors.Is(err, errBadEvent) {
+					t.Fatalf("contains(%s) error = %v, want %v", tt.version, err, errBadEvent)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("contains(%s): %v", tt.version, err)
+			}
+			if got != tt.want || fix != tt.fix {
+				t.Errorf("contains(%s) = %v, %q, want %v, %q", tt.version, got, fix, tt.want, tt.fix)
+			}
+		})
+	}
+}
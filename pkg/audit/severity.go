@@ -0,0 +1,147 @@
+package audit
+
+import (
+	"math"
+	"strings"
+)
+
+// Severity is how serious an advisory is
+type Severity int
+
+const (
+	SeverityUnknown Severity = iota
+	SeverityLow
+	SeverityModerate
+	SeverityHigh
+	SeverityCritical
+)
+
+// String returns the severity's name
+func (s Severity) String() string {
+	switch s {
+	case SeverityLow:
+		return "low"
+	case SeverityModerate:
+		return "moderate"
+	case SeverityHigh:
+		return "high"
+	case SeverityCritical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
+
+// severityOf rates an advisory for one affected package. A label from the database, as GitHub
+// advisories carry, is used if present; otherwise the highest CVSS v3 base score decides.
+func severityOf(advisory *Advisory, affected Affected) Severity {
+	for _, label := range []string{affected.Database.Severity, advisory.Database.Severity} {
+		if s := parseLabel(label); s != SeverityUnknown {
+			return s
+		}
+	}
+
+	best := SeverityUnknown
+	for _, score := range append(affected.Severity, advisory.Severity...) {
+		if score.Type != "CVSS_V3" {
+			continue
+		}
+		if base, ok := cvss3Score(score.Score); ok {
+			best = max(best, rate(base))
+		}
+	}
+	return best
+}
+
+// parseLabel reads a severity label such as GitHub's LOW, MODERATE, HIGH or CRITICAL
+func parseLabel(label string) Severity {
+	switch strings.ToUpper(label) {
+	case "LOW":
+		return SeverityLow
+	case "MODERATE", "MEDIUM":
+		return SeverityModerate
+	case "HIGH":
+		return SeverityHigh
+	case "CRITICAL":
+		return SeverityCritical
+	default:
+		return SeverityUnknown
+	}
+}
+
+// rate maps a CVSS base score to its qualitative rating
+func rate(score float64) Severity {
+	switch {
+	case score >= 9:
+		return SeverityCritical
+	case score >= 7:
+		return SeverityHigh
+	case score >= 4:
+		return SeverityModerate
+	case score > 0:
+		return SeverityLow
+	default:
+		return SeverityUnknown
+	}
+}
+
+// cvss3Weights are the CVSS v3.x base metric values. Privileges required is weighted higher when the scope changes.
+var cvss3Weights = map[string]map[string]float64{
+	"AV": {"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2},
+	"AC": {"L": 0.77, "H": 0.44},
+	"PR": {"N": 0.85, "L": 0.62, "H": 0.27},
+	"UI": {"N": 0.85, "R": 0.62},
+	"C":  {"H": 0.56, "L": 0.22, "N": 0},
+	"I":  {"H": 0.56, "L": 0.22, "N": 0},
+	"A":  {"H": 0.56, "L": 0.22, "N": 0},
+}
+
+// cvss3Score computes the base score of a CVSS v3.0 or v3.1 vector such as
+// CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H
+func cvss3Score(vector string) (float64, bool) {
+	metrics := make(map[string]string)
+	for _, part := range strings.Split(vector, "/") {
+		if name, value, ok := strings.Cut(part, ":"); ok {
+			metrics[name] = value
+		}
+	}
+
+	weights := make(map[string]float64)
+	for name, values := range cvss3Weights {
+		w, ok := values[metrics[name]]
+		if !ok {
+			return 0, false
+		}
+		weights[name] = w
+	}
+	changed := metrics["S"] == "C"
+	if metrics["S"] != "U" && !changed {
+		return 0, false
+	}
+	if changed {
+		weights["PR"] = map[string]float64{"N": 0.85, "L": 0.68, "H": 0.5}[metrics["PR"]]
+	}
+
+	iss := 1 - (1-weights["C"])*(1-weights["I"])*(1-weights["A"])
+	impact := 6.42 * iss
+	if changed {
+		impact = 7.52*(iss-0.029) - 3.25*math.Pow(iss-0.02, 15)
+	}
+	if impact <= 0 {
+		return 0, true
+	}
+	exploitability := 8.22 * weights["AV"] * weights["AC"] * weights["PR"] * weights["UI"]
+	if changed {
+		return roundUp(min(1.08*(impact+exploitability), 10)), true
+	}
+	return roundUp(min(impact+exploitability, 10)), true
+}
+
+// roundUp rounds up to one decimal place the way the CVSS v3.1 specification defines it
+func roundUp(x float64) float64 {
+	n := int(math.Round(x * 100000))
+	if n%10000 == 0 {
+		return float64(n) / 100000
+	}
+	return float64(n/10000+1) / 10
+}
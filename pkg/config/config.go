@@ -1,50 +1,129 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 )
 
 // Config holds the application configuration
 type Config struct {
-	ParentDirectory    string `mapstructure:"parent_directory"`
-	ConfigFileLocation string
+	Version            int                 `mapstructure:"version" yaml:"version"`
+	ParentDirectory    string              `mapstructure:"parent_directory" yaml:"parent_directory"`
+	StaleAfterDays     int                 `mapstructure:"stale_after_days" yaml:"stale_after_days"`
+	DismissedStale     []string            `mapstructure:"dismissed_stale" yaml:"dismissed_stale"`
+	ArchiveDirectory   string              `mapstructure:"archive_directory" yaml:"archive_directory"`
+	ArchiveExclude     []string            `mapstructure:"archive_exclude" yaml:"archive_exclude"`
+	Watch              bool                `mapstructure:"watch" yaml:"watch"`
+	Editor             string              `mapstructure:"editor" yaml:"editor"`
+	FileManager        string              `mapstructure:"file_manager" yaml:"file_manager"`
+	MaxJobs            int                 `mapstructure:"max_jobs" yaml:"max_jobs"`
+	AdvisoryDirectory  string              `mapstructure:"advisory_directory" yaml:"advisory_directory"`
+	LicenseDeny        []string            `mapstructure:"license_deny" yaml:"license_deny"`
+	Keys               map[string][]string `mapstructure:"keys" yaml:"keys"`
+	Theme              string              `mapstructure:"theme" yaml:"theme"`
+	ConfigFileLocation string              `mapstructure:"-" yaml:"-"`
+
+	// MigratedFrom is the schema version the config file was upgraded from on load, or 0
+	MigratedFrom int `mapstructure:"-" yaml:"-"`
+	// BackupLocation is where the pre-migration config file was copied to
+	BackupLocation string `mapstructure:"-" yaml:"-"`
+
+	// sources records where each non-default value came from
+	sources map[string]string
+	// overrides holds the values set from the environment, and replaced the values they hid
+	overrides map[string]any
+	replaced  map[string]any
+	// unexpanded holds the paths that started with ~, as written and as expanded
+	unexpanded map[string][2]string
 }
 
 // DefaultConfig returns a config with default values
 func DefaultConfig() *Config {
 	homeDir, _ := os.UserHomeDir()
 	return &Config{
-		ParentDirectory: filepath.Join(homeDir, "projects"),
+		Version:           Version,
+		ParentDirectory:   filepath.Join(homeDir, "projects"),
+		StaleAfterDays:    90,
+		ArchiveDirectory:  filepath.Join(DataDir(), "archives"),
+		ArchiveExclude:    []string{".venv", "__pycache__", ".pytest_cache", ".mypy_cache", ".ruff_cache"},
+		Watch:             true,
+		MaxJobs:           4,
+		AdvisoryDirectory: filepath.Join(DataDir(), "advisories"),
+		LicenseDeny:       []string{"AGPL-3.0-only", "AGPL-3.0-or-later", "SSPL-1.0"},
+		Theme:             "auto",
+		sources:           make(map[string]string),
+		overrides:         make(map[string]any),
+		replaced:          make(map[string]any),
+		unexpanded:        make(map[string][2]string),
 	}
 }
 
-// LoadConfig loads the configuration from file or creates a default one if it doesn't exist
-func LoadConfig() (*Config, error) {
-	config := DefaultConfig()
+// ThemeDirectory returns the directory holding user theme files
+func (c *Config) ThemeDirectory() string {
+	return filepath.Join(ConfigDir(), "themes")
+}
 
-	// Set up config file path
-	homeDir, err := os.UserHomeDir()
+// LoadConfig loads the configuration from path, $TUV_CONFIG or the default location, applies
+// TUV_* environment overrides, and returns defaults if the file doesn't exist yet
+func LoadConfig(path string) (*Config, error) {
+	config, from, migrated, err := read(path)
 	if err != nil {
 		return nil, err
 	}
 
-	configDir := filepath.Join(homeDir, ".config", "tuv")
-	configFile := filepath.Join(configDir, "config.yaml")
+	// Rewrite a config a migration changed, keeping the original next to it
+	if migrated {
+		backupPath, err := backup(config.ConfigFileLocation, from)
+		if err != nil {
+			return nil, fmt.Errorf("backing up %s before migrating it: %w", config.ConfigFileLocation, err)
+		}
+		if err := config.Save(); err != nil {
+			return nil, err
+		}
+		config.MigratedFrom = from
+		config.BackupLocation = backupPath
+	}
+
+	return config, nil
+}
+
+// InspectConfig loads the configuration like LoadConfig but never rewrites the file, and returns
+// whatever values it could read along with every problem found in them. The config is nil only
+// when the file can't be read at all.
+func InspectConfig(path string) (*Config, error) {
+	config, _, _, err := read(path)
+	return config, err
+}
+
+// read loads, migrates in memory, overrides and validates the configuration, returning the
+// version the file was written in and whether a migration changed it. Problems with individual values are all reported together,
+// with the config filled in as far as it could be.
+func read(path string) (*Config, int, bool, error) {
+	config := DefaultConfig()
+
+	configFile := configPath(path)
 	config.ConfigFileLocation = configFile
 
 	// Create config directory if it doesn't exist
-	if err := os.MkdirAll(configDir, 0755); err != nil {
-		return nil, err
+	if err := os.MkdirAll(filepath.Dir(configFile), 0755); err != nil {
+		return nil, 0, false, err
 	}
 
 	// Check if config file exists
 	if _, err := os.Stat(configFile); os.IsNotExist(err) {
 		// Return the default config without saving it
 		// This will trigger the first run screen
-		return config, nil
+		envErr := config.applyEnv()
+		config.expandHome()
+		if envErr != nil {
+			return config, Version, false, fmt.Errorf("environment:\n%w", envErr)
+		}
+		return config, Version, false, nil
 	}
 
 	// Load existing config
@@ -52,24 +131,43 @@ func LoadConfig() (*Config, error) {
 	viper.SetConfigType("yaml")
 
 	if err := viper.ReadInConfig(); err != nil {
-		return nil, err
+		return nil, 0, false, err
 	}
 
-	if err := viper.Unmarshal(config); err != nil {
-		return nil, err
+	raw := viper.AllSettings()
+	from, migrated, err := migrate(raw)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("%s: %w", configFile, err)
 	}
 
-	return config, nil
-}
+	decodeErr := decode(raw, config)
+	config.markFileSources(raw)
+	envErr := config.applyEnv()
+	// Paths from the file and the environment may both start with ~
+	config.expandHome()
 
-// Save writes the config to the config file
-func (c *Config) Save() error {
-	viper.SetConfigFile(c.ConfigFileLocation)
-	viper.SetConfigType("yaml")
+	var problems []error
+	if err := errors.Join(decodeErr, config.Validate()); err != nil {
+		problems = append(problems, fmt.Errorf("%s:\n%w", configFile, err))
+	}
+	if envErr != nil {
+		problems = append(problems, fmt.Errorf("environment:\n%w", envErr))
+	}
 
-	viper.Set("parent_directory", c.ParentDirectory)
+	return config, from, migrated, errors.Join(problems...)
+}
 
-	return viper.WriteConfig()
+// Save writes every field of the config to the config file, leaving out unchanged environment overrides
+func (c *Config) Save() error {
+	c.Version = Version
+	data, err := yaml.Marshal(c.withoutOverrides())
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.ConfigFileLocation), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(c.ConfigFileLocation, data, 0644)
 }
 
 // IsFirstRun checks if this is the first run of the application
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfigKeepsFile(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+	}{
+		{name: "without version", file: "parent_directory: ~/code\nstale_after_days: 30\n"},
+		{name: "current version", file: "version: 1\nparent_directory: ~/code\narchive_directory: ~/archives\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+			path := filepath.Join(home, "config.yaml")
+			if err := os.WriteFile(path, []byte(tt.file), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			config, err := LoadConfig(path)
+			if err != nil {
+				t.Fatalf("LoadConfig: %v", err)
+			}
+			if config.MigratedFrom != 0 {
+				t.Errorf("MigratedFrom = %d, want 0", config.MigratedFrom)
+			}
+			if data, _ := os.ReadFile(path); string(data) != tt.file {
+				t.Errorf("config file was rewritten:\n%s", data)
+			}
+			if backups, _ := filepath.Glob(path + ".v*.bak"); len(backups) > 0 {
+				t.Errorf("config file was backed up: %v", backups)
+			}
+			if want := filepath.Join(home, "code"); config.ParentDirectory != want {
+				t.Errorf("ParentDirectory = %s, want %s", config.ParentDirectory, want)
+			}
+		})
+	}
+}
+
+func TestSaveKeepsHomePaths(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	path := filepath.Join(home, "config.yaml")
+	if err := os.WriteFile(path, []byte("parent_directory: ~/code\narchive_directory: ~/archives\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	config.ArchiveDirectory = filepath.Join(home, "elsewhere")
+	if err := config.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	for _, want := range []string{"parent_directory: ~/code\n", "archive_directory: " + filepath.Join(home, "elsewhere") + "\n"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("saved config doesn't contain %q:\n%s", want, data)
+		}
+	}
+}
+
+func TestEnvHomePaths(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("TUV_PARENT_DIRECTORY", "~/from-env")
+	t.Setenv("TUV_ADVISORY_DIRECTORY", "~")
+	path := filepath.Join(home, "config.yaml")
+	if err := os.WriteFile(path, []byte("parent_directory: ~/code\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if want := filepath.Join(home, "from-env"); config.ParentDirectory != want {
+		t.Errorf("ParentDirectory = %s, want %s", config.ParentDirectory, want)
+	}
+	if config.AdvisoryDirectory != home {
+		t.Errorf("AdvisoryDirectory = %s, want %s", config.AdvisoryDirectory, home)
+	}
+
+	if err := config.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "parent_directory: ~/code\n") || strings.Contains(string(data), "from-env") {
+		t.Errorf("saved config has the environment's path instead of the file's:\n%s", data)
+	}
+}
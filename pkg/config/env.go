@@ -0,0 +1,168 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// EnvPrefix starts the environment variables that override config fields, e.g. TUV_MAX_JOBS
+const EnvPrefix = "TUV_"
+
+// Where a config value came from
+const (
+	SourceDefault = "default"
+	SourceFile    = "file"
+)
+
+// EnvVar returns the environment variable that overrides a config key
+func EnvVar(key string) string {
+	return EnvPrefix + strings.ToUpper(key)
+}
+
+// Setting is one effective config value and where it came from
+type Setting struct {
+	Key    string
+	Value  string
+	Source string
+}
+
+// field returns the struct field for a config key, if there is one
+func (c *Config) field(key string) (reflect.Value, bool) {
+	v := reflect.ValueOf(c).Elem()
+	t := v.Type()
+	for i := range t.NumField() {
+		if t.Field(i).Tag.Get("mapstructure") == key {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// overridable lists the config keys that can be set from the environment, in field order
+func overridable() []string {
+	return slices.DeleteFunc(knownKeys(), func(key string) bool {
+		return key == "version"
+	})
+}
+
+// markFileSources records which keys were set by the config file
+func (c *Config) markFileSources(raw map[string]any) {
+	for key := range raw {
+		if _, ok := c.field(key); ok {
+			c.sources[key] = SourceFile
+		}
+	}
+}
+
+// applyEnv overrides fields from TUV_* variables, keeping the replaced values so Save doesn't persist the overrides
+func (c *Config) applyEnv() error {
+	var errs []string
+	for _, key := range overridable() {
+		value, ok := os.LookupEnv(EnvVar(key))
+		if !ok {
+			continue
+		}
+		field, _ := c.field(key)
+		parsed, err := parseEnv(field.Type(), value)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", EnvVar(key), err))
+			continue
+		}
+		c.replaced[key] = field.Interface()
+		field.Set(parsed)
+		c.overrides[key] = parsed.Interface()
+		c.sources[key] = "env " + EnvVar(key)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+// parseEnv converts an environment variable's text into a value for a field of type t
+func parseEnv(t reflect.Type, value string) (reflect.Value, error) {
+	switch t.Kind() {
+	case reflect.String:
+		return reflect.ValueOf(value), nil
+	case reflect.Int:
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("%q is not a whole number", value)
+		}
+		return reflect.ValueOf(n), nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("%q is not true or false", value)
+		}
+		return reflect.ValueOf(b), nil
+	case reflect.Slice:
+		return reflect.ValueOf(SplitList(value)), nil
+	case reflect.Map:
+		keys, err := ParseKeys(value)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(keys), nil
+	}
+	return reflect.Value{}, fmt.Errorf("unsupported type %s", t)
+}
+
+// withoutOverrides returns a copy of the config with environment overrides swapped back for the
+// values they replaced and expanded paths written with ~ again, unless the value has been changed since
+func (c *Config) withoutOverrides() *Config {
+	out := *c
+	// Paths are expanded after the overrides are applied, so they are put back first
+	for key, paths := range c.unexpanded {
+		if field, _ := out.field(key); field.String() == paths[1] {
+			field.SetString(paths[0])
+		}
+	}
+	for key, override := range c.overrides {
+		field, _ := out.field(key)
+		if reflect.DeepEqual(field.Interface(), override) {
+			field.Set(reflect.ValueOf(c.replaced[key]))
+		}
+	}
+	return &out
+}
+
+// Source reports where a config key's value came from: default, file or the environment variable
+func (c *Config) Source(key string) string {
+	if source, ok := c.sources[key]; ok {
+		return source
+	}
+	return SourceDefault
+}
+
+// EnvOverride returns the environment variable that set a config key, if one did
+func (c *Config) EnvOverride(key string) (string, bool) {
+	_, ok := c.overrides[key]
+	return EnvVar(key), ok
+}
+
+// Effective lists every config value in field order along with where it came from
+func (c *Config) Effective() []Setting {
+	var settings []Setting
+	for _, key := range overridable() {
+		field, _ := c.field(key)
+		settings = append(settings, Setting{Key: key, Value: formatValue(field), Source: c.Source(key)})
+	}
+	return settings
+}
+
+// formatValue renders a field the way it would be written in an environment variable
+func formatValue(v reflect.Value) string {
+	switch value := v.Interface().(type) {
+	case []string:
+		return strings.Join(value, ", ")
+	case map[string][]string:
+		return FormatKeys(value)
+	default:
+		return fmt.Sprint(value)
+	}
+}
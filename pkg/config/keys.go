@@ -0,0 +1,57 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// FormatKeys renders keybinding overrides as "action=key key, ..." in action order
+func FormatKeys(keys map[string][]string) string {
+	var parts []string
+	for _, action := range slices.Sorted(maps.Keys(keys)) {
+		names := slices.Clone(keys[action])
+		for i, k := range names {
+			if k == " " {
+				names[i] = "space"
+			}
+		}
+		parts = append(parts, action+"="+strings.Join(names, " "))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ParseKeys reads keybinding overrides written by FormatKeys
+func ParseKeys(value string) (map[string][]string, error) {
+	keys := make(map[string][]string)
+	for _, part := range SplitList(value) {
+		action, names, ok := strings.Cut(part, "=")
+		action = strings.TrimSpace(action)
+		if !ok || action == "" {
+			return nil, fmt.Errorf("%q should look like action=keys", part)
+		}
+		fields := strings.Fields(names)
+		for i, k := range fields {
+			if k == "space" {
+				fields[i] = " "
+			}
+		}
+		keys[action] = fields
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	return keys, nil
+}
+
+// SplitList parses a comma-separated list, dropping empty entries
+func SplitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// xdgDir returns $env/tuv when the variable holds an absolute path, otherwise ~/fallback/tuv,
+// following the XDG base directory specification
+func xdgDir(env string, fallback ...string) string {
+	if dir := os.Getenv(env); filepath.IsAbs(dir) {
+		return filepath.Join(dir, "tuv")
+	}
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(append(append([]string{homeDir}, fallback...), "tuv")...)
+}
+
+// ConfigDir returns the directory holding the config file and user themes
+func ConfigDir() string {
+	return xdgDir("XDG_CONFIG_HOME", ".config")
+}
+
+// DataDir returns the directory where tuv keeps its own data, such as archives and run history
+func DataDir() string {
+	return xdgDir("XDG_DATA_HOME", ".local", "share")
+}
+
+// CacheDir returns the directory for data tuv can download or rebuild again
+func CacheDir() string {
+	return xdgDir("XDG_CACHE_HOME", ".cache")
+}
+
+// configPath picks the config file: an explicit path, then $TUV_CONFIG, then config.yaml in ConfigDir
+func configPath(explicit string) string {
+	if explicit != "" {
+		return explicit
+	}
+	if path := os.Getenv("TUV_CONFIG"); path != "" {
+		return path
+	}
+	return filepath.Join(ConfigDir(), "config.yaml")
+}
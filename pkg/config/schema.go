@@ -0,0 +1,176 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// Version is the config schema version this build reads and writes
+const Version = 1
+
+// migrations upgrade a raw config one schema version at a time: migrations[i] turns version i+1
+// into i+2 and reports whether it changed anything. There are none yet.
+var migrations []func(raw map[string]any) bool
+
+// migrate brings a raw config up to the current version. It returns the version the file was
+// written in, taking a file without a version as current, and whether a migration changed it.
+func migrate(raw map[string]any) (int, bool, error) {
+	version := Version
+	if value, ok := raw["version"]; ok {
+		n, ok := value.(int)
+		if !ok || n < 1 {
+			return 0, false, fmt.Errorf("version: %v is not a schema version", value)
+		}
+		version = n
+	}
+	if version > Version {
+		return version, false, fmt.Errorf("version: %d is newer than this tuv supports (%d), please upgrade tuv", version, Version)
+	}
+
+	changed := false
+	for v := version; v < Version; v++ {
+		if migrations[v-1](raw) {
+			changed = true
+		}
+	}
+	raw["version"] = Version
+	return version, changed, nil
+}
+
+// knownKeys returns the keys a config file may contain, taken from the Config struct tags
+func knownKeys() []string {
+	var keys []string
+	t := reflect.TypeOf(Config{})
+	for i := 0; i < t.NumField(); i++ {
+		if tag := t.Field(i).Tag.Get("mapstructure"); tag != "" && tag != "-" {
+			keys = append(keys, tag)
+		}
+	}
+	return keys
+}
+
+// decode strictly fills config from a raw config, reporting unknown keys and values of the wrong type.
+// Every value that could be read is filled in even when others couldn't.
+func decode(raw map[string]any, config *Config) error {
+	known := knownKeys()
+	var problems []error
+	for key := range raw {
+		if !slices.Contains(known, key) {
+			problems = append(problems, fmt.Errorf("%s: unknown key (valid keys: %s)", key, strings.Join(known, ", ")))
+		}
+	}
+
+	// Unknown keys are left out of the decode, so the known ones are still read
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{Result: config})
+	if err != nil {
+		return err
+	}
+	if err := decoder.Decode(raw); err != nil {
+		var decodeErr *mapstructure.Error
+		if errors.As(err, &decodeErr) {
+			for _, problem := range decodeErr.Errors {
+				// Match the "key: problem" form of the other messages
+				if key, rest, ok := strings.Cut(strings.TrimPrefix(problem, "'"), "' "); ok {
+					problem = key + ": " + rest
+				}
+				problems = append(problems, errors.New(problem))
+			}
+		} else {
+			problems = append(problems, err)
+		}
+	}
+	return errors.Join(problems...)
+}
+
+// homePaths are the keys of the path fields that may start with ~
+var homePaths = []string{"parent_directory", "archive_directory", "advisory_directory"}
+
+// expandHome replaces a leading ~ in the path fields with the user's home directory, remembering
+// the paths as written so Save doesn't replace them with the expanded ones
+func (c *Config) expandHome() {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return
+	}
+	for _, key := range homePaths {
+		field, _ := c.field(key)
+		path := field.String()
+		if path == "~" || strings.HasPrefix(path, "~/") || strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+			expanded := filepath.Join(homeDir, path[1:])
+			field.SetString(expanded)
+			c.unexpanded[key] = [2]string{path, expanded}
+		}
+	}
+}
+
+// Validate checks every field's value and reports all problems at once
+func (c *Config) Validate() error {
+	var problems []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			problems = append(problems, fmt.Errorf(format, args...))
+		}
+	}
+
+	// A missing parent directory is only a warning, as it may be on a disk that isn't mounted
+	check(c.ParentDirectory != "", "parent_directory: is required")
+	if info, err := os.Stat(c.ParentDirectory); err == nil {
+		check(info.IsDir(), "parent_directory: %s is not a directory", c.ParentDirectory)
+	}
+
+	check(c.StaleAfterDays >= 1, "stale_after_days: must be at least 1, got %d", c.StaleAfterDays)
+	check(c.MaxJobs >= 1, "max_jobs: must be at least 1, got %d", c.MaxJobs)
+
+	check(c.ArchiveDirectory != "", "archive_directory: is required")
+	if info, err := os.Stat(c.ArchiveDirectory); err == nil {
+		check(info.IsDir(), "archive_directory: %s is not a directory", c.ArchiveDirectory)
+	}
+
+	check(c.AdvisoryDirectory != "", "advisory_directory: is required")
+	if info, err := os.Stat(c.AdvisoryDirectory); err == nil {
+		check(info.IsDir(), "advisory_directory: %s is not a directory", c.AdvisoryDirectory)
+	}
+
+	for i, path := range c.DismissedStale {
+		check(path != "", "dismissed_stale[%d]: is empty", i)
+	}
+	for i, name := range c.ArchiveExclude {
+		check(name != "", "archive_exclude[%d]: is empty", i)
+	}
+	for i, pattern := range c.LicenseDeny {
+		_, err := path.Match(pattern, "")
+		check(pattern != "" && err == nil, "license_deny[%d]: %q is not a license identifier or glob", i, pattern)
+	}
+	for action, keys := range c.Keys {
+		check(len(keys) > 0, "keys.%s: needs at least one key", action)
+	}
+
+	return errors.Join(problems...)
+}
+
+// Warnings returns problems that don't stop tuv from starting but that the user should know about
+func (c *Config) Warnings() []string {
+	var warnings []string
+	if _, err := os.Stat(c.ParentDirectory); c.ParentDirectory != "" && os.IsNotExist(err) {
+		warnings = append(warnings, fmt.Sprintf("parent_directory: %s does not exist", c.ParentDirectory))
+	}
+	return warnings
+}
+
+// backup copies the config file aside before a migration rewrites it
+func backup(path string, version int) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	backupPath := fmt.Sprintf("%s.v%d.bak", path, version)
+	return backupPath, os.WriteFile(backupPath, data, 0644)
+}
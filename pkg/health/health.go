@@ -0,0 +1,264 @@
+package health
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/chloebubble/tuv/pkg/pyversion"
+	"github.com/chloebubble/tuv/pkg/scanner"
+)
+
+// eolWarning is how far ahead an upcoming end of life is reported
+const eolWarning = 180 * 24 * time.Hour
+
+// Severity is how much a finding matters
+type Severity int
+
+const (
+	Info Severity = iota
+	Warning
+	Error
+)
+
+// String returns the severity's name
+func (s Severity) String() string {
+	switch s {
+	case Error:
+		return "error"
+	case Warning:
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+// Fix is an action that resolves a finding
+type Fix struct {
+	Label string
+	Run   func(ctx context.Context, projectPath string) (string, error)
+}
+
+// Finding is a problem one check found in one project
+type Finding struct {
+	Project  scanner.UVProject
+	Check    string
+	Severity Severity
+	Message  string
+	Fix      *Fix
+}
+
+// Check inspects a project and reports what it finds
+type Check struct {
+	Name string
+	run  func(p scanner.UVProject, now time.Time) []Finding
+}
+
+var (
+	lockFix = &Fix{Label: "uv lock", Run: scanner.LockProject}
+	syncFix = &Fix{Label: "uv sync", Run: scanner.SyncProject}
+	// readmeFix writes a README.md with just the project's name as its heading
+	readmeFix = &Fix{Label: "create README.md", Run: func(_ context.Context, projectPath string) (string, error) {
+		path := filepath.Join(projectPath, "README.md")
+		return "", os.WriteFile(path, []byte("# "+filepath.Base(projectPath)+"\n"), 0644)
+	}}
+)
+
+// Checks lists every check the health dashboard runs, in the order findings are grouped by default
+var Checks = []Check{
+	{Name: "lock", run: checkLock},
+	{Name: "venv", run: checkVenv},
+	{Name: "interpreter", run: checkInterpreter},
+	{Name: "requires-python", run: checkRequiresPython},
+	{Name: "end of life", run: checkEndOfLife},
+	{Name: "readme", run: checkReadme},
+	{Name: "license", run: checkLicense},
+}
+
+// Run checks every project, stopping early if ctx is cancelled. progress, if set, is called with each project.
+func Run(ctx context.Context, projects []scanner.UVProject, now time.Time, progress func(done int, project scanner.UVProject)) ([]Finding, error) {
+	var findings []Finding
+	for i, project := range projects {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if progress != nil {
+			progress(i, project)
+		}
+		findings = append(findings, CheckProject(project, now)...)
+	}
+	return findings, nil
+}
+
+// CheckProject runs every check against one project
+func CheckProject(project scanner.UVProject, now time.Time) []Finding {
+	var findings []Finding
+	for _, check := range Checks {
+		for _, finding := range check.run(project, now) {
+			finding.Project = project
+			finding.Check = check.Name
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+// checkLock reports a missing uv.lock or one that no longer matches pyproject.toml
+func checkLock(p scanner.UVProject, _ time.Time) []Finding {
+	if p.Tool != scanner.ToolUV {
+		return nil
+	}
+	if !p.HasLock && !p.IsWorkspaceMember() {
+		return []Finding{{Severity: Warning, Message: "No uv.lock", Fix: lockFix}}
+	}
+	if p.Drift != nil && len(p.Drift.Lock) > 0 {
+		return []Finding{{Severity: Warning, Message: "uv.lock is out of date: " + strings.Join(p.Drift.Lock, "; "), Fix: lockFix}}
+	}
+	return nil
+}
+
+// checkVenv reports a uv project without a virtual environment, or one that differs from uv.lock
+func checkVenv(p scanner.UVProject, _ time.Time) []Finding {
+	if p.Tool != scanner.ToolUV || p.IsWorkspaceMember() {
+		return nil
+	}
+	if !p.HasVenv {
+		return []Finding{{Severity: Warning, Message: "No virtual environment", Fix: syncFix}}
+	}
+	if p.Drift != nil && len(p.Drift.Venv) > 0 {
+		return []Finding{{Severity: Warning, Message: ".venv differs from uv.lock: " + strings.Join(p.Drift.Venv, "; "), Fix: syncFix}}
+	}
+	return nil
+}
+
+// checkInterpreter reports a venv built with a different Python than .python-version asks for
+func checkInterpreter(p scanner.UVProject, _ time.Time) []Finding {
+	pin, ok := pinnedVersion(p)
+	if !ok {
+		return nil
+	}
+	venv, ok := VenvVersion(p.Path)
+	if !ok || pin.Matches(venv) {
+		return nil
+	}
+	return []Finding{{
+		Severity: Error,
+		Message:  fmt.Sprintf("The venv uses Python %s but .python-version asks for %s", venv, pin),
+		Fix:      syncFix,
+	}}
+}
+
+// checkRequiresPython reports a .python-version pin that requires-python does not allow
+func checkRequiresPython(p scanner.UVProject, _ time.Time) []Finding {
+	pin, ok := pinnedVersion(p)
+	if !ok {
+		return nil
+	}
+	pyproject, err := scanner.ReadPyproject(p.Path)
+	if err != nil || pyproject.Project.RequiresPython == "" {
+		return nil
+	}
+	spec, err := pyversion.ParseSpecifier(pyproject.Project.RequiresPython)
+	if err != nil {
+		return []Finding{{Severity: Warning, Message: "Can't read requires-python: " + err.Error()}}
+	}
+	if spec.AllowsPin(pin) {
+		return nil
+	}
+	return []Finding{{
+		Severity: Error,
+		Message:  fmt.Sprintf(".python-version pins %s but requires-python is %s", pin, spec),
+	}}
+}
+
+// checkEndOfLife reports Python versions that are out of support or will be soon
+func checkEndOfLife(p scanner.UVProject, now time.Time) []Finding {
+	version, ok := pinnedVersion(p)
+	if !ok {
+		if version, ok = VenvVersion(p.Path); !ok {
+			return nil
+		}
+	}
+	eol, ok := pyversion.EndOfLife(version)
+	if !ok {
+		return nil
+	}
+	switch {
+	case now.After(eol):
+		return []Finding{{
+			Severity: Error,
+			Message:  fmt.Sprintf("Python %s reached end of life on %s", version.Minor(), eol.Format("2006-01-02")),
+		}}
+	case eol.Sub(now) < eolWarning:
+		return []Finding{{
+			Severity: Info,
+			Message:  fmt.Sprintf("Python %s reaches end of life on %s", version.Minor(), eol.Format("2006-01-02")),
+		}}
+	}
+	return nil
+}
+
+// checkReadme reports a project without a README
+func checkReadme(p scanner.UVProject, _ time.Time) []Finding {
+	if hasFile(p.Path, "README*", "readme*") {
+		return nil
+	}
+	return []Finding{{Severity: Info, Message: "No README", Fix: readmeFix}}
+}
+
+// checkLicense reports a project with neither a license file nor a license field
+func checkLicense(p scanner.UVProject, _ time.Time) []Finding {
+	if hasFile(p.Path, "LICEN[CS]E*", "licen[cs]e*", "COPYING*") {
+		return nil
+	}
+	if data, err := os.ReadFile(filepath.Join(p.Path, "pyproject.toml")); err == nil && strings.Contains(string(data), "license") {
+		return nil
+	}
+	return []Finding{{Severity: Info, Message: "No license file or license field"}}
+}
+
+// pinnedVersion returns the version in the project's .python-version file
+func pinnedVersion(p scanner.UVProject) (pyversion.Version, bool) {
+	data, err := os.ReadFile(filepath.Join(p.Path, ".python-version"))
+	if err != nil {
+		return pyversion.Version{}, false
+	}
+	v, err := pyversion.ParsePin(string(data))
+	return v, err == nil
+}
+
+// VenvVersion returns the Python version the project's .venv was created with, from its pyvenv.cfg
+func VenvVersion(projectPath string) (pyversion.Version, bool) {
+	f, err := os.Open(filepath.Join(projectPath, ".venv", "pyvenv.cfg"))
+	if err != nil {
+		return pyversion.Version{}, false
+	}
+	defer f.Close()
+
+	lines := bufio.NewScanner(f)
+	for lines.Scan() {
+		key, value, ok := strings.Cut(lines.Text(), "=")
+		if !ok {
+			continue
+		}
+		if key = strings.TrimSpace(key); key == "version" || key == "version_info" {
+			v, err := pyversion.Parse(value)
+			return v, err == nil
+		}
+	}
+	return pyversion.Version{}, false
+}
+
+// hasFile reports whether any of the glob patterns match a file in dir
+func hasFile(dir string, patterns ...string) bool {
+	for _, pattern := range patterns {
+		if matches, _ := filepath.Glob(filepath.Join(dir, pattern)); len(matches) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,306 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Status is the lifecycle state of a job
+type Status int
+
+const (
+	Queued Status = iota
+	Running
+	Succeeded
+	Failed
+	Cancelled
+)
+
+// String returns a short label for the status
+func (s Status) String() string {
+	switch s {
+	case Queued:
+		return "queued"
+	case Running:
+		return "running"
+	case Succeeded:
+		return "done"
+	case Failed:
+		return "failed"
+	case Cancelled:
+		return "cancelled"
+	default:
+		return "unknown"
+	}
+}
+
+// Done reports whether the job has finished, one way or another
+func (s Status) Done() bool {
+	return s == Succeeded || s == Failed || s == Cancelled
+}
+
+// Reporter lets a running job publish its progress (0 to 1, or negative if unknown) and a short message
+type Reporter func(progress float64, message string)
+
+// Func is the work a job performs. It is always called exactly once; if the job was cancelled
+// while queued, ctx is already done so the function can report the cancellation itself.
+type Func func(ctx context.Context, report Reporter) (any, error)
+
+// Job is a snapshot of a submitted job
+type Job struct {
+	ID       int
+	Name     string
+	Status   Status
+	Progress float64
+	Message  string
+	Queued   time.Time
+	Started  time.Time
+	Finished time.Time
+	Result   any
+	Err      error
+}
+
+// Elapsed returns how long the job has been running, or ran for
+func (j Job) Elapsed() time.Duration {
+	switch {
+	case j.Started.IsZero():
+		return 0
+	case j.Finished.IsZero():
+		return time.Since(j.Started)
+	default:
+		return j.Finished.Sub(j.Started)
+	}
+}
+
+// Manager runs jobs concurrently up to a parallelism limit and publishes every change as an event
+type Manager struct {
+	mu      sync.Mutex
+	jobs    []*Job
+	cancels map[int]context.CancelFunc
+	nextID  int
+	events  chan Job
+	// limit is how many jobs may run at once and running how many do; slotFreed is signalled
+	// whenever either changes or a queued job is cancelled
+	limit     int
+	running   int
+	slotFreed *sync.Cond
+	// queue holds snapshots not yet delivered on events; wake tells dispatch there is something in it
+	queue []Job
+	wake  chan struct{}
+}
+
+// NewManager creates a manager that runs at most parallelism jobs at once
+func NewManager(parallelism int) *Manager {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	m := &Manager{
+		cancels: make(map[int]context.CancelFunc),
+		events:  make(chan Job),
+		limit:   parallelism,
+		wake:    make(chan struct{}, 1),
+	}
+	m.slotFreed = sync.NewCond(&m.mu)
+	go m.dispatch()
+	return m
+}
+
+// SetParallelism changes how many jobs may run at once. Queued jobs start under the new limit;
+// running jobs are left to finish even if there are now more of them than it allows.
+func (m *Manager) SetParallelism(parallelism int) {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	m.mu.Lock()
+	m.limit = parallelism
+	m.slotFreed.Broadcast()
+	m.mu.Unlock()
+}
+
+// Events delivers a snapshot of a job whenever its state or progress changes
+func (m *Manager) Events() <-chan Job {
+	return m.events
+}
+
+// Submit queues a job and returns its ID
+func (m *Manager) Submit(name string, fn Func) int {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	m.mu.Lock()
+	m.nextID++
+	job := &Job{ID: m.nextID, Name: name, Status: Queued, Progress: -1, Queued: time.Now()}
+	m.jobs = append(m.jobs, job)
+	m.cancels[job.ID] = cancel
+	m.mu.Unlock()
+
+	m.publish(job)
+	go m.run(ctx, job, fn)
+	return job.ID
+}
+
+// run waits for a free slot, then runs the job
+func (m *Manager) run(ctx context.Context, job *Job, fn Func) {
+	m.mu.Lock()
+	for m.running >= m.limit && ctx.Err() == nil {
+		m.slotFreed.Wait()
+	}
+	if ctx.Err() == nil {
+		m.running++
+		defer func() {
+			m.mu.Lock()
+			m.running--
+			m.slotFreed.Broadcast()
+			m.mu.Unlock()
+		}()
+	}
+	m.mu.Unlock()
+
+	m.update(job, func(j *Job) {
+		if ctx.Err() == nil {
+			j.Status = Running
+			j.Started = time.Now()
+		}
+	})
+
+	result, err := fn(ctx, func(progress float64, message string) {
+		m.update(job, func(j *Job) {
+			j.Progress = progress
+			j.Message = message
+		})
+	})
+
+	m.update(job, func(j *Job) {
+		j.Finished = time.Now()
+		j.Result = result
+		j.Err = err
+		switch {
+		case ctx.Err() != nil:
+			// Killed processes report their own error; the cause is still the cancellation
+			j.Status = Cancelled
+		case err != nil:
+			j.Status = Failed
+		default:
+			j.Status = Succeeded
+			j.Progress = 1
+		}
+	})
+
+	m.mu.Lock()
+	if cancel, ok := m.cancels[job.ID]; ok {
+		cancel()
+		delete(m.cancels, job.ID)
+	}
+	m.mu.Unlock()
+}
+
+// update applies a change to a job under the lock and publishes the result
+func (m *Manager) update(job *Job, change func(*Job)) {
+	m.mu.Lock()
+	change(job)
+	m.mu.Unlock()
+	m.publish(job)
+}
+
+// publish queues a snapshot of the job for the events channel without waiting for it to be read.
+// A snapshot still waiting to be delivered is replaced by the job's newer one unless it is a final
+// state, so progress reports can't pile up while a finished job is never lost.
+func (m *Manager) publish(job *Job) {
+	m.mu.Lock()
+	snapshot := *job
+	replaced := false
+	for i := range m.queue {
+		if m.queue[i].ID == snapshot.ID && !m.queue[i].Status.Done() {
+			m.queue[i] = snapshot
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		m.queue = append(m.queue, snapshot)
+	}
+	m.mu.Unlock()
+
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+}
+
+// dispatch delivers queued snapshots on the events channel in order, one at a time so that
+// snapshots published while it waits for a reader can still be coalesced
+func (m *Manager) dispatch() {
+	for range m.wake {
+		for {
+			m.mu.Lock()
+			if len(m.queue) == 0 {
+				m.mu.Unlock()
+				break
+			}
+			snapshot := m.queue[0]
+			m.queue = m.queue[1:]
+			m.mu.Unlock()
+			m.events <- snapshot
+		}
+	}
+}
+
+// Cancel requests cancellation of a queued or running job and reports whether it was still active
+func (m *Manager) Cancel(id int) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cancel, ok := m.cancels[id]
+	if ok {
+		cancel()
+		// Wake queued jobs so a cancelled one stops waiting for a slot
+		m.slotFreed.Broadcast()
+	}
+	return ok
+}
+
+// Jobs returns snapshots of all jobs, newest first
+func (m *Manager) Jobs() []Job {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	jobs := make([]Job, 0, len(m.jobs))
+	for i := len(m.jobs) - 1; i >= 0; i-- {
+		jobs = append(jobs, *m.jobs[i])
+	}
+	return jobs
+}
+
+// Active returns how many jobs are queued or running
+func (m *Manager) Active() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	active := 0
+	for _, job := range m.jobs {
+		if !job.Status.Done() {
+			active++
+		}
+	}
+	return active
+}
+
+// Prune forgets finished jobs, keeping the most recent keep of them
+func (m *Manager) Prune(keep int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	finished := 0
+	var jobs []*Job
+	for i := len(m.jobs) - 1; i >= 0; i-- {
+		job := m.jobs[i]
+		if job.Status.Done() {
+			finished++
+			if finished > keep {
+				continue
+			}
+		}
+		jobs = append([]*Job{job}, jobs...)
+	}
+	m.jobs = jobs
+}
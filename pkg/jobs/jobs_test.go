@@ -0,0 +1,111 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// gate counts the jobs running at once and holds them until released
+type gate struct {
+	mu      sync.Mutex
+	running int
+	most    int
+	release chan struct{}
+}
+
+func (g *gate) job(ctx context.Context, report Reporter) (any, error) {
+	g.mu.Lock()
+	g.running++
+	g.most = max(g.most, g.running)
+	g.mu.Unlock()
+	defer func() {
+		g.mu.Lock()
+		g.running--
+		g.mu.Unlock()
+	}()
+	select {
+	case <-g.release:
+	case <-ctx.Done():
+	}
+	return nil, ctx.Err()
+}
+
+// waitRunning waits until exactly want jobs are running and stay that way for a moment
+func (g *gate) waitRunning(t *testing.T, want int) {
+	t.Helper()
+	running := func() int {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		return g.running
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if running() == want {
+			time.Sleep(20 * time.Millisecond)
+			if running() == want {
+				return
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("%d jobs running, want %d", running(), want)
+}
+
+func TestSetParallelismAppliesToQueuedJobs(t *testing.T) {
+	g := &gate{release: make(chan struct{}, 10)}
+	m := NewManager(1)
+	for i := 0; i < 3; i++ {
+		m.Submit("job", g.job)
+	}
+	g.waitRunning(t, 1)
+
+	// A raised limit starts jobs that were already queued, and newer jobs don't run beyond it
+	m.SetParallelism(2)
+	g.waitRunning(t, 2)
+	m.Submit("job", g.job)
+	m.Submit("job", g.job)
+	g.waitRunning(t, 2)
+
+	// A lowered limit lets running jobs finish but holds back queued ones, old and new
+	m.SetParallelism(1)
+	m.Submit("job", g.job)
+	g.waitRunning(t, 2)
+	g.release <- struct{}{}
+	g.release <- struct{}{}
+	g.waitRunning(t, 1)
+
+	close(g.release)
+	for m.Active() > 0 {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if g.most > 2 {
+		t.Errorf("%d jobs ran at once, limit was at most 2", g.most)
+	}
+}
+
+func TestCancelQueuedJob(t *testing.T) {
+	g := &gate{release: make(chan struct{})}
+	m := NewManager(1)
+	m.Submit("running", g.job)
+	g.waitRunning(t, 1)
+	queued := m.Submit("queued", g.job)
+
+	if !m.Cancel(queued) {
+		t.Fatal("Cancel reported the queued job as inactive")
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for m.Active() > 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("cancelled job is still waiting for a slot")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	for _, job := range m.Jobs() {
+		if job.ID == queued && (job.Status != Cancelled || !job.Started.IsZero()) {
+			t.Errorf("cancelled job ended %s, started %v", job.Status, job.Started)
+		}
+	}
+	close(g.release)
+}
@@ -0,0 +1,292 @@
+package licenses
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"encoding/csv"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/chloebubble/tuv/pkg/scanner"
+)
+
+// Status is how a package's license stands against the deny list
+type Status int
+
+const (
+	Allowed Status = iota
+	// Unknown means the license couldn't be classified, so it needs a person to look at it
+	Unknown
+	Denied
+)
+
+// String returns the status's name
+func (s Status) String() string {
+	switch s {
+	case Denied:
+		return "denied"
+	case Unknown:
+		return "unknown"
+	default:
+		return "allowed"
+	}
+}
+
+// Entry is the license of one package
+type Entry struct {
+	Package string
+	Version string
+	// License is the SPDX expression the metadata was classified as, or empty if it couldn't be
+	License string
+	// Declared is the metadata the license was read from, as written
+	Declared string
+	// From names the metadata field Declared came from
+	From   string
+	Status Status
+	// Denied lists the licenses on the deny list that made the package denied
+	Denied []string
+}
+
+// Report is the license report for one project
+type Report struct {
+	Project scanner.UVProject
+	// Own is the project's own license from pyproject.toml
+	Own     Entry
+	Entries []Entry
+	// Skipped says why the project's packages weren't read, e.g. it has no venv
+	Skipped string
+}
+
+// Count returns how many of the report's packages have the status
+func (r Report) Count(status Status) int {
+	n := 0
+	for _, e := range r.Entries {
+		if e.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// Run reports on every project, stopping early if ctx is cancelled. progress, if set, is called
+// with each project. Workspace members share their root's venv, so only roots are reported on.
+func Run(ctx context.Context, projects []scanner.UVProject, deny []string, progress func(done int, project scanner.UVProject)) ([]Report, error) {
+	var reports []Report
+	for i, project := range projects {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if project.IsWorkspaceMember() {
+			continue
+		}
+		if progress != nil {
+			progress(i, project)
+		}
+		reports = append(reports, Check(project, deny))
+	}
+	return reports, nil
+}
+
+// Check reads the licenses of the packages installed in a project's venv and the project's own license
+func Check(project scanner.UVProject, deny []string) Report {
+	report := Report{Project: project, Own: ownLicense(project, deny)}
+
+	sitePackages := scanner.SitePackages(project.Path)
+	if sitePackages == "" {
+		report.Skipped = "no .venv"
+		return report
+	}
+	distInfos, _ := filepath.Glob(filepath.Join(sitePackages, "*.dist-info"))
+	for _, distInfo := range distInfos {
+		// The project's own editable install is already covered by Own
+		if entry, ok := readDistInfo(distInfo, deny); ok && scanner.NormalizeName(entry.Package) != scanner.NormalizeName(report.Own.Package) {
+			report.Entries = append(report.Entries, entry)
+		}
+	}
+	slices.SortFunc(report.Entries, func(a, b Entry) int {
+		return cmp.Or(cmp.Compare(b.Status, a.Status), cmp.Compare(scanner.NormalizeName(a.Package), scanner.NormalizeName(b.Package)))
+	})
+	return report
+}
+
+// ownLicense classifies the license field of the project's pyproject.toml
+func ownLicense(project scanner.UVProject, deny []string) Entry {
+	entry := Entry{Package: project.Name, From: "pyproject.toml"}
+	pyproject, err := scanner.ReadPyproject(project.Path)
+	if err != nil {
+		return judge(entry, deny)
+	}
+	entry.Package = cmp.Or(pyproject.Project.Name, project.Name)
+	entry.Version = pyproject.Project.Version
+
+	switch license := pyproject.Project.License.(type) {
+	case string:
+		entry.Declared = license
+	case map[string]any:
+		if text, ok := license["text"].(string); ok {
+			entry.Declared = text
+		} else if file, ok := license["file"].(string); ok {
+			entry.From = file
+			if data, err := os.ReadFile(filepath.Join(project.Path, file)); err == nil {
+				entry.Declared = string(data)
+			}
+		}
+	}
+	return judge(entry, deny)
+}
+
+// readDistInfo reads the name, version and license of an installed package from its METADATA.
+// License-Expression is preferred, then license classifiers, then the free-form License field.
+func readDistInfo(distInfo string, deny []string) (Entry, bool) {
+	headers, err := readMetadata(filepath.Join(distInfo, "METADATA"))
+	if err != nil {
+		return Entry{}, false
+	}
+	entry := Entry{Package: first(headers["Name"]), Version: first(headers["Version"])}
+
+	var classified []string
+	for _, classifier := range headers["Classifier"] {
+		name, ok := strings.CutPrefix(classifier, "License :: ")
+		if !ok {
+			continue
+		}
+		parts := strings.Split(name, " :: ")
+		if id, ok := Classify(parts[len(parts)-1]); ok && !slices.Contains(classified, id) {
+			classified = append(classified, id)
+		}
+	}
+
+	switch {
+	case first(headers["License-Expression"]) != "":
+		entry.Declared, entry.From = first(headers["License-Expression"]), "License-Expression"
+	case len(classified) > 0:
+		entry.Declared, entry.From = strings.Join(classified, " OR "), "Classifier"
+	default:
+		entry.Declared, entry.From = first(headers["License"]), "License"
+	}
+	return judge(entry, deny), true
+}
+
+// judge classifies an entry's declared license and checks it against the deny list
+func judge(entry Entry, deny []string) Entry {
+	license, ok := Classify(entry.Declared)
+	if !ok {
+		entry.Status = Unknown
+		return entry
+	}
+	entry.License = license
+	expr, err := ParseExpression(license)
+	if err != nil {
+		entry.Status = Unknown
+		return entry
+	}
+	if entry.Denied = expr.Denied(deny); len(entry.Denied) > 0 {
+		entry.Status = Denied
+	} else if expr.Undecided(deny) {
+		entry.Status = Unknown
+	}
+	return entry
+}
+
+// readMetadata parses the header block of a core metadata file. Continuation lines, which start with
+// whitespace, are joined to the header before them.
+func readMetadata(path string) (map[string][]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	headers := make(map[string][]string)
+	var last string
+	lines := bufio.NewScanner(f)
+	lines.Buffer(make([]byte, 64*1024), 1024*1024)
+	for lines.Scan() {
+		line := lines.Text()
+		if line == "" {
+			break
+		}
+		if (line[0] == ' ' || line[0] == '\t') && last != "" {
+			values := headers[last]
+			values[len(values)-1] += "\n" + strings.TrimSpace(line)
+			continue
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		last = name
+		headers[name] = append(headers[name], strings.TrimSpace(value))
+	}
+	return headers, lines.Err()
+}
+
+// first returns the first value of a header, or ""
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// Usage is a package version and license seen across projects, with the projects that install it
+type Usage struct {
+	Entry
+	Projects []string
+}
+
+// Combine merges the reports' packages into one list, denied and unknown licenses first
+func Combine(reports []Report) []Usage {
+	var usages []Usage
+	index := make(map[string]int)
+	for _, report := range reports {
+		for _, entry := range report.Entries {
+			key := scanner.NormalizeName(entry.Package) + " " + entry.Version
+			i, ok := index[key]
+			if !ok {
+				i = len(usages)
+				index[key] = i
+				usages = append(usages, Usage{Entry: entry})
+			}
+			usages[i].Projects = append(usages[i].Projects, report.Project.Name)
+		}
+	}
+	slices.SortFunc(usages, func(a, b Usage) int {
+		return cmp.Or(cmp.Compare(b.Status, a.Status), cmp.Compare(scanner.NormalizeName(a.Package), scanner.NormalizeName(b.Package)), cmp.Compare(a.Version, b.Version))
+	})
+	return usages
+}
+
+// WriteCSV writes one row per project and package, starting with each project's own license.
+// A project whose packages weren't read gets a row with the status "skipped" and the reason.
+func WriteCSV(w io.Writer, reports []Report) error {
+	out := csv.NewWriter(w)
+	if err := out.Write([]string{"project", "package", "version", "license", "status", "denied", "source", "declared", "reason"}); err != nil {
+		return err
+	}
+	row := func(project string, e Entry) []string {
+		declared, _, _ := strings.Cut(e.Declared, "\n")
+		return []string{project, e.Package, e.Version, e.License, e.Status.String(), strings.Join(e.Denied, " "), e.From, declared, ""}
+	}
+	for _, report := range reports {
+		if err := out.Write(row(report.Project.Name, report.Own)); err != nil {
+			return err
+		}
+		if report.Skipped != "" {
+			if err := out.Write([]string{report.Project.Name, "", "", "", "skipped", "", "", "", report.Skipped}); err != nil {
+				return err
+			}
+		}
+		for _, entry := range report.Entries {
+			if err := out.Write(row(report.Project.Name, entry)); err != nil {
+				return err
+			}
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
@@ -0,0 +1,373 @@
+package licenses
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+)
+
+// spdxIDs are the SPDX license identifiers tuv recognises, in their canonical spelling
+var spdxIDs = []string{
+	"0BSD", "AFL-3.0", "AGPL-3.0-only", "AGPL-3.0-or-later", "Apache-1.1", "Apache-2.0", "Artistic-2.0",
+	"BlueOak-1.0.0", "BSD-2-Clause", "BSD-3-Clause", "BSD-4-Clause", "BSL-1.0", "CC-BY-4.0", "CC-BY-SA-4.0",
+	"CC0-1.0", "CDDL-1.0", "CNRI-Python", "EPL-1.0", "EPL-2.0", "EUPL-1.2", "GPL-2.0-only", "GPL-2.0-or-later",
+	"GPL-3.0-only", "GPL-3.0-or-later", "HPND", "ISC", "LGPL-2.0-only", "LGPL-2.0-or-later", "LGPL-2.1-only",
+	"LGPL-2.1-or-later", "LGPL-3.0-only", "LGPL-3.0-or-later", "MIT", "MIT-0", "MIT-CMU", "MPL-1.1", "MPL-2.0",
+	"NCSA", "OpenSSL", "PSF-2.0", "Python-2.0", "SSPL-1.0", "Unlicense", "UPL-1.0", "WTFPL", "X11", "Zlib",
+	"ZPL-2.1",
+}
+
+// spdxExceptions are the license exceptions that may follow WITH
+var spdxExceptions = []string{
+	"Classpath-exception-2.0", "GCC-exception-3.1", "LLVM-exception", "Qt-LGPL-exception-1.1",
+}
+
+// aliases maps license names found in package metadata and trove classifiers to SPDX identifiers.
+// Keys are lower case. Names that don't say which variant they mean map to a LicenseRef.
+var aliases = map[string]string{
+	"mit":                                  "MIT",
+	"mit license":                          "MIT",
+	"the mit license":                      "MIT",
+	"expat":                                "MIT",
+	"mit no attribution license":           "MIT-0",
+	"apache":                               "Apache-2.0",
+	"apache 2":                             "Apache-2.0",
+	"apache 2.0":                           "Apache-2.0",
+	"apache-2":                             "Apache-2.0",
+	"apache license":                       "Apache-2.0",
+	"apache license 2.0":                   "Apache-2.0",
+	"apache license, version 2.0":          "Apache-2.0",
+	"apache license version 2.0":           "Apache-2.0",
+	"apache software license":              "Apache-2.0",
+	"apache software license 2.0":          "Apache-2.0",
+	"asl 2":                                "Apache-2.0",
+	"bsd":                                  "LicenseRef-BSD",
+	"bsd license":                          "LicenseRef-BSD",
+	"new bsd":                              "BSD-3-Clause",
+	"new bsd license":                      "BSD-3-Clause",
+	"modified bsd":                         "BSD-3-Clause",
+	"bsd 3-clause":                         "BSD-3-Clause",
+	"bsd 3-clause license":                 "BSD-3-Clause",
+	"3-clause bsd":                         "BSD-3-Clause",
+	"bsd-3":                                "BSD-3-Clause",
+	"simplified bsd":                       "BSD-2-Clause",
+	"bsd 2-clause":                         "BSD-2-Clause",
+	"bsd 2-clause license":                 "BSD-2-Clause",
+	"freebsd":                              "BSD-2-Clause",
+	"isc":                                  "ISC",
+	"isc license":                          "ISC",
+	"isc license (iscl)":                   "ISC",
+	"mozilla public license 2.0":           "MPL-2.0",
+	"mozilla public license 2.0 (mpl 2.0)": "MPL-2.0",
+	"mpl 2.0":                              "MPL-2.0",
+	"mpl-2":                                "MPL-2.0",
+	"mozilla public license 1.1 (mpl 1.1)": "MPL-1.1",
+	"python software foundation license":   "PSF-2.0",
+	"psf":                                  "PSF-2.0",
+	"psf license":                          "PSF-2.0",
+	"the unlicense":                        "Unlicense",
+	"the unlicense (unlicense)":            "Unlicense",
+	"unlicense":                            "Unlicense",
+	"public domain":                        "LicenseRef-Public-Domain",
+	"cc0 1.0 universal (cc0 1.0) public domain dedication": "CC0-1.0",
+	"zope public license":                                "ZPL-2.1",
+	"eclipse public license 1.0 (epl-1.0)":               "EPL-1.0",
+	"eclipse public license 2.0 (epl-2.0)":               "EPL-2.0",
+	"academic free license (afl)":                        "AFL-3.0",
+	"artistic license":                                   "Artistic-2.0",
+	"boost software license 1.0 (bsl-1.0)":               "BSL-1.0",
+	"zlib/libpng license":                                "Zlib",
+	"historical permission notice and disclaimer (hpnd)": "HPND",
+	"universal permissive license (upl)":                 "UPL-1.0",
+	"server side public license (sspl)":                  "SSPL-1.0",
+	"european union public licence 1.2 (eupl 1.2)":       "EUPL-1.2",
+	"other/proprietary license":                          "LicenseRef-Proprietary",
+	"proprietary":                                        "LicenseRef-Proprietary",
+	"gpl":                                                "LicenseRef-GPL",
+	"gplv2":                                              "GPL-2.0-only",
+	"gplv2+":                                             "GPL-2.0-or-later",
+	"gplv3":                                              "GPL-3.0-only",
+	"gplv3+":                                             "GPL-3.0-or-later",
+	"lgpl":                                               "LicenseRef-LGPL",
+	"lgplv3":                                             "LGPL-3.0-only",
+	"lgplv3+":                                            "LGPL-3.0-or-later",
+	"agplv3":                                             "AGPL-3.0-only",
+	"agplv3+":                                            "AGPL-3.0-or-later",
+	"gnu general public license (gpl)":                   "LicenseRef-GPL",
+	"gnu general public license v2 (gplv2)":              "GPL-2.0-only",
+	"gnu general public license v2 or later (gplv2+)":         "GPL-2.0-or-later",
+	"gnu general public license v3 (gplv3)":                   "GPL-3.0-only",
+	"gnu general public license v3 or later (gplv3+)":         "GPL-3.0-or-later",
+	"gnu lesser general public license v2 (lgplv2)":           "LGPL-2.0-only",
+	"gnu lesser general public license v2 or later (lgplv2+)": "LGPL-2.0-or-later",
+	"gnu lesser general public license v3 (lgplv3)":           "LGPL-3.0-only",
+	"gnu lesser general public license v3 or later (lgplv3+)": "LGPL-3.0-or-later",
+	"gnu library or lesser general public license (lgpl)":     "LicenseRef-LGPL",
+	"gnu affero general public license v3":                    "AGPL-3.0-only",
+	"gnu affero general public license v3 or later (agplv3+)": "AGPL-3.0-or-later",
+}
+
+// textHints recognise a license from the opening of its full text, for License fields and
+// license files that hold the text rather than a name. The first match wins.
+var textHints = []struct{ phrase, id string }{
+	{"apache license", "Apache-2.0"},
+	{"mit license", "MIT"},
+	{"permission is hereby granted, free of charge", "MIT"},
+	{"bsd 3-clause", "BSD-3-Clause"},
+	{"bsd 2-clause", "BSD-2-Clause"},
+	{"redistribution and use in source and binary forms", "LicenseRef-BSD"},
+	{"mozilla public license version 2.0", "MPL-2.0"},
+	{"gnu affero general public license", "AGPL-3.0-only"},
+	{"gnu lesser general public license", "LicenseRef-LGPL"},
+	{"gnu general public license", "LicenseRef-GPL"},
+	{"this is free and unencumbered software released into the public domain", "Unlicense"},
+	{"isc license", "ISC"},
+}
+
+// Classify turns license metadata into an SPDX expression. It accepts SPDX expressions, common
+// license names, trove classifier names and the opening of a license text.
+func Classify(text string) (string, bool) {
+	text = strings.TrimSpace(text)
+	if text == "" || strings.EqualFold(text, "unknown") {
+		return "", false
+	}
+	if expr, err := ParseExpression(text); err == nil {
+		return expr.String(), true
+	}
+	first, _, _ := strings.Cut(text, "\n")
+	if id, ok := aliases[strings.ToLower(strings.TrimSpace(first))]; ok {
+		return id, true
+	}
+
+	opening := strings.ToLower(text[:min(len(text), 400)])
+	opening = strings.Join(strings.Fields(opening), " ")
+	for _, hint := range textHints {
+		if strings.Contains(opening, hint.phrase) {
+			return hint.id, true
+		}
+	}
+	return "", false
+}
+
+// canonicalID returns the canonical spelling of a license identifier, mapping deprecated GNU
+// identifiers such as GPL-3.0 and GPL-3.0+ to their -only and -or-later forms
+func canonicalID(id string) (string, bool) {
+	if strings.HasPrefix(id, "LicenseRef-") && len(id) > len("LicenseRef-") {
+		return id, true
+	}
+	suffix := ""
+	if base, ok := strings.CutSuffix(id, "+"); ok {
+		id, suffix = base, "-or-later"
+	}
+	for _, known := range spdxIDs {
+		switch {
+		case strings.EqualFold(known, id+suffix):
+			return known, true
+		case suffix == "" && strings.EqualFold(known, id+"-only"):
+			return known, true
+		}
+	}
+	return "", false
+}
+
+// licenseFamilies maps the LicenseRefs of names that don't say which variant they mean to the
+// prefix of the identifiers they could stand for
+var licenseFamilies = map[string]string{
+	"LicenseRef-BSD":  "BSD-",
+	"LicenseRef-GPL":  "GPL-",
+	"LicenseRef-LGPL": "LGPL-",
+}
+
+// Expression is a parsed SPDX license expression
+type Expression struct {
+	// Op is "AND" or "OR" for a compound expression, or empty for a single license
+	Op        string
+	ID        string
+	Exception string
+	Terms     []Expression
+}
+
+// ParseExpression parses an SPDX license expression such as "MIT OR (Apache-2.0 AND BSD-3-Clause)".
+// Every identifier must be one tuv knows or a LicenseRef.
+func ParseExpression(text string) (Expression, error) {
+	p := &exprParser{tokens: strings.Fields(strings.NewReplacer("(", " ( ", ")", " ) ").Replace(text))}
+	if len(p.tokens) == 0 {
+		return Expression{}, fmt.Errorf("empty license expression")
+	}
+	expr, err := p.or()
+	if err != nil {
+		return Expression{}, err
+	}
+	if p.pos < len(p.tokens) {
+		return Expression{}, fmt.Errorf("unexpected %q in license expression", p.tokens[p.pos])
+	}
+	return expr, nil
+}
+
+// exprParser is a recursive descent parser over an expression's tokens. AND binds tighter than OR.
+type exprParser struct {
+	tokens []string
+	pos    int
+}
+
+// peek returns the next token without consuming it, or "" at the end
+func (p *exprParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+// or parses terms joined by OR
+func (p *exprParser) or() (Expression, error) {
+	return p.compound("OR", p.and)
+}
+
+// and parses terms joined by AND
+func (p *exprParser) and() (Expression, error) {
+	return p.compound("AND", p.atom)
+}
+
+// compound parses terms joined by op, collapsing a single term to itself
+func (p *exprParser) compound(op string, term func() (Expression, error)) (Expression, error) {
+	first, err := term()
+	if err != nil {
+		return Expression{}, err
+	}
+	terms := []Expression{first}
+	for strings.EqualFold(p.peek(), op) {
+		p.pos++
+		next, err := term()
+		if err != nil {
+			return Expression{}, err
+		}
+		terms = append(terms, next)
+	}
+	if len(terms) == 1 {
+		return first, nil
+	}
+	return Expression{Op: op, Terms: terms}, nil
+}
+
+// atom parses a parenthesised expression or a single license with an optional WITH exception
+func (p *exprParser) atom() (Expression, error) {
+	token := p.peek()
+	p.pos++
+	switch {
+	case token == "":
+		return Expression{}, fmt.Errorf("license expression ends early")
+	case token == "(":
+		expr, err := p.or()
+		if err != nil {
+			return Expression{}, err
+		}
+		if p.peek() != ")" {
+			return Expression{}, fmt.Errorf("missing ) in license expression")
+		}
+		p.pos++
+		return expr, nil
+	}
+
+	id, ok := canonicalID(token)
+	if !ok {
+		return Expression{}, fmt.Errorf("%q is not a known SPDX license identifier", token)
+	}
+	expr := Expression{ID: id}
+	if strings.EqualFold(p.peek(), "WITH") {
+		p.pos++
+		exception := p.peek()
+		i := slices.IndexFunc(spdxExceptions, func(e string) bool { return strings.EqualFold(e, exception) })
+		if i < 0 {
+			return Expression{}, fmt.Errorf("%q is not a known SPDX license exception", exception)
+		}
+		p.pos++
+		expr.Exception = spdxExceptions[i]
+	}
+	return expr, nil
+}
+
+// String formats the expression in canonical form, parenthesising nested compounds
+func (e Expression) String() string {
+	if e.Op == "" {
+		if e.Exception != "" {
+			return e.ID + " WITH " + e.Exception
+		}
+		return e.ID
+	}
+	var parts []string
+	for _, term := range e.Terms {
+		if term.Op != "" {
+			parts = append(parts, "("+term.String()+")")
+		} else {
+			parts = append(parts, term.String())
+		}
+	}
+	return strings.Join(parts, " "+e.Op+" ")
+}
+
+// Denied returns the licenses on the deny list that the expression can't avoid: a choice (OR) is
+// only denied if every option is, while a combination (AND) is denied if any part is. Deny list
+// entries are identifiers or globs such as "GPL-*", matched without regard to case.
+func (e Expression) Denied(deny []string) []string {
+	switch e.Op {
+	case "OR":
+		var denied []string
+		for _, term := range e.Terms {
+			d := term.Denied(deny)
+			if len(d) == 0 {
+				return nil
+			}
+			denied = append(denied, d...)
+		}
+		return denied
+	case "AND":
+		var denied []string
+		for _, term := range e.Terms {
+			denied = append(denied, term.Denied(deny)...)
+		}
+		return denied
+	}
+	for _, pattern := range deny {
+		if ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(e.ID)); ok {
+			return []string{e.ID}
+		}
+	}
+	return nil
+}
+
+// Undecided reports whether the expression can't be checked against the deny list because it
+// can't avoid a license family, such as GPL without a version, of which some variants are denied
+func (e Expression) Undecided(deny []string) bool {
+	switch e.Op {
+	case "OR":
+		// A choice is decided if any option is allowed, or if every option is denied
+		undecided := false
+		for _, term := range e.Terms {
+			switch {
+			case term.Undecided(deny):
+				undecided = true
+			case len(term.Denied(deny)) == 0:
+				return false
+			}
+		}
+		return undecided
+	case "AND":
+		for _, term := range e.Terms {
+			if term.Undecided(deny) {
+				return true
+			}
+		}
+		return false
+	}
+	prefix, ok := licenseFamilies[e.ID]
+	if !ok {
+		return false
+	}
+	for _, id := range spdxIDs {
+		if strings.HasPrefix(id, prefix) && len(Expression{ID: id}.Denied(deny)) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+package licenses
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+		ok   bool
+	}{
+		{name: "identifier", text: "MIT", want: "MIT", ok: true},
+		{name: "expression", text: "mit or apache-2.0", want: "MIT OR Apache-2.0", ok: true},
+		{name: "deprecated identifier", text: "GPL-3.0+", want: "GPL-3.0-or-later", ok: true},
+		{name: "name", text: "Apache Software License", want: "Apache-2.0", ok: true},
+		{name: "classifier name", text: "GNU General Public License v3 (GPLv3)", want: "GPL-3.0-only", ok: true},
+		{name: "name without variant", text: "BSD License", want: "LicenseRef-BSD", ok: true},
+		{
+			name: "license text",
+			text: "Copyright (c) 2024 Someone\n\nPermission is hereby granted, free of charge, to any person obtaining a copy",
+			want: "MIT",
+			ok:   true,
+		},
+		{name: "empty", text: "  ", ok: false},
+		{name: "unknown", text: "UNKNOWN", ok: false},
+		{name: "unrecognised", text: "Custom license, see website", ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Classify(tt.text)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("Classify(%q) = %q, %v, want %q, %v", tt.text, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestParseExpression(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    string
+		wantErr bool
+	}{
+		{text: "MIT", want: "MIT"},
+		{text: "gpl-2.0", want: "GPL-2.0-only"},
+		{text: "Apache-2.0 WITH llvm-exception", want: "Apache-2.0 WITH LLVM-exception"},
+		{text: "MIT AND (Apache-2.0 OR BSD-3-Clause)", want: "MIT AND (Apache-2.0 OR BSD-3-Clause)"},
+		{text: "MIT OR Apache-2.0 AND BSD-3-Clause", want: "MIT OR (Apache-2.0 AND BSD-3-Clause)"},
+		{text: "((MIT))", want: "MIT"},
+		{text: "LicenseRef-Proprietary", want: "LicenseRef-Proprietary"},
+		{text: "", wantErr: true},
+		{text: "MIT OR", wantErr: true},
+		{text: "(MIT OR ISC", wantErr: true},
+		{text: "MIT)", wantErr: true},
+		{text: "MIT ISC", wantErr: true},
+		{text: "Not-A-License", wantErr: true},
+		{text: "LicenseRef-", wantErr: true},
+		{text: "GPL-2.0-only WITH Made-Up-exception", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			expr, err := ParseExpression(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseExpression(%q) error = %v, want error %v", tt.text, err, tt.wantErr)
+			}
+			if err == nil && expr.String() != tt.want {
+				t.Errorf("ParseExpression(%q) = %q, want %q", tt.text, expr.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestDenied(t *testing.T) {
+	tests := []struct {
+		expression string
+		deny       []string
+		want       []string
+	}{
+		{expression: "MIT", deny: []string{"GPL-*"}},
+		{expression: "GPL-3.0-only", deny: []string{"GPL-*"}, want: []string{"GPL-3.0-only"}},
+		{expression: "GPL-3.0-only", deny: []string{"gpl-3.0-ONLY"}, want: []string{"GPL-3.0-only"}},
+		{expression: "LGPL-3.0-only", deny: []string{"GPL-*"}},
+		{expression: "MIT OR GPL-3.0-only", deny: []string{"GPL-*"}},
+		{expression: "MIT AND GPL-3.0-only", deny: []string{"GPL-*"}, want: []string{"GPL-3.0-only"}},
+		{
+			expression: "GPL-2.0-only OR AGPL-3.0-only",
+			deny:       []string{"GPL-*", "AGPL-*"},
+			want:       []string{"GPL-2.0-only", "AGPL-3.0-only"},
+		},
+		{expression: "MIT", deny: nil},
+		{expression: "LicenseRef-GPL", deny: []string{"GPL-*"}},
+		{expression: "LicenseRef-Proprietary", deny: []string{"LicenseRef-*"}, want: []string{"LicenseRef-Proprietary"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			expr, err := ParseExpression(tt.expression)
+			if err != nil {
+				t.Fatalf("ParseExpression(%q): %v", tt.expression, err)
+			}
+			if got := expr.Denied(tt.deny); !slices.Equal(got, tt.want) {
+				t.Errorf("%q Denied(%q) = %q, want %q", tt.expression, tt.deny, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUndecided(t *testing.T) {
+	tests := []struct {
+		expression string
+		deny       []string
+		want       bool
+	}{
+		{expression: "LicenseRef-GPL", deny: []string{"GPL-3.0-*"}, want: true},
+		{expression: "LicenseRef-LGPL", deny: []string{"LGPL-*"}, want: true},
+		{expression: "LicenseRef-GPL", deny: []string{"AGPL-*"}},
+		{expression: "LicenseRef-BSD", deny: []string{"GPL-*"}},
+		{expression: "LicenseRef-Proprietary", deny: []string{"GPL-*"}},
+		{expression: "GPL-3.0-only", deny: []string{"GPL-*"}},
+		{expression: "MIT OR LicenseRef-GPL", deny: []string{"GPL-*"}},
+		{expression: "MIT AND LicenseRef-GPL", deny: []string{"GPL-*"}, want: true},
+		{expression: "LicenseRef-GPL OR GPL-3.0-only", deny: []string{"GPL-*"}, want: true},
+		{expression: "GPL-2.0-only OR AGPL-3.0-only", deny: []string{"GPL-*", "AGPL-*"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			expr, err := ParseExpression(tt.expression)
+			if err != nil {
+				t.Fatalf("ParseExpression(%q): %v", tt.expression, err)
+			}
+			if got := expr.Undecided(tt.deny); got != tt.want {
+				t.Errorf("%q Undecided(%q) = %v, want %v", tt.expression, tt.deny, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package migrate
+
+import "strings"
+
+// DiffKind says whether a diff line was kept, removed or added
+type DiffKind int
+
+const (
+	DiffSame DiffKind = iota
+	DiffRemoved
+	DiffAdded
+	// DiffGap stands in for unchanged lines left out of the diff
+	DiffGap
+)
+
+// DiffLine is one line of a diff
+type DiffLine struct {
+	Kind DiffKind
+	Text string
+}
+
+// Diff compares two texts line by line, keeping context unchanged lines around each change
+func Diff(before, after string, context int) []DiffLine {
+	a := splitLines(before)
+	b := splitLines(after)
+
+	// Longest common subsequence table, filled from the end
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var lines []DiffLine
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, DiffLine{DiffSame, a[i]})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, DiffLine{DiffRemoved, a[i]})
+			i++
+		default:
+			lines = append(lines, DiffLine{DiffAdded, b[j]})
+			j++
+		}
+	}
+	return trimContext(lines, context)
+}
+
+// trimContext replaces runs of unchanged lines further than context lines from a change with a gap
+func trimContext(lines []DiffLine, context int) []DiffLine {
+	near := make([]bool, len(lines))
+	for i, line := range lines {
+		if line.Kind == DiffSame {
+			continue
+		}
+		for k := max(i-context, 0); k <= min(i+context, len(lines)-1); k++ {
+			near[k] = true
+		}
+	}
+
+	var out []DiffLine
+	for i, line := range lines {
+		if near[i] {
+			out = append(out, line)
+		} else if len(out) == 0 || out[len(out)-1].Kind != DiffGap {
+			out = append(out, DiffLine{Kind: DiffGap})
+		}
+	}
+	return out
+}
+
+// splitLines splits text into lines, treating an empty text as no lines
+func splitLines(text string) []string {
+	text = strings.TrimRight(text, "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
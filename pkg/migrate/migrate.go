@@ -0,0 +1,119 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/chloebubble/tuv/pkg/scanner"
+)
+
+// backupSuffix is added to the name of every file a migration replaces
+const backupSuffix = ".bak"
+
+// Plan is a prepared migration of one project to uv. Nothing is written until Apply.
+type Plan struct {
+	Dir  string
+	Tool scanner.Tool
+	// Before is the current pyproject.toml, empty if the project has none
+	Before string
+	// After is the pyproject.toml the migration writes
+	After string
+	// Retire lists files made obsolete by pyproject.toml and uv.lock, moved aside on Apply
+	Retire []string
+	// Notes lists anything that could not be converted automatically
+	Notes []string
+}
+
+// Supported reports whether tuv can convert a project of the given tool itself
+func Supported(tool scanner.Tool) bool {
+	return tool == scanner.ToolPoetry || tool == scanner.ToolRequirements
+}
+
+// Prepare works out how a Poetry or requirements.txt project would be converted to uv
+func Prepare(dir string, tool scanner.Tool) (*Plan, error) {
+	before, err := os.ReadFile(filepath.Join(dir, "pyproject.toml"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	plan := &Plan{Dir: dir, Tool: tool, Before: string(before)}
+
+	switch tool {
+	case scanner.ToolPoetry:
+		err = plan.fromPoetry()
+	case scanner.ToolRequirements:
+		err = plan.fromRequirements()
+	default:
+		err = fmt.Errorf("converting %s projects is not supported", tool.Label())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// Backups returns where Apply keeps each original file
+func (p *Plan) Backups() []string {
+	var backups []string
+	if p.Before != "" {
+		backups = append(backups, "pyproject.toml"+backupSuffix)
+	}
+	for _, name := range p.Retire {
+		backups = append(backups, name+backupSuffix)
+	}
+	return backups
+}
+
+// Apply backs up the original files and writes the new pyproject.toml
+func (p *Plan) Apply() error {
+	for _, backup := range p.Backups() {
+		if _, err := os.Stat(filepath.Join(p.Dir, backup)); err == nil {
+			return fmt.Errorf("%s already exists, move it out of the way first", backup)
+		}
+	}
+
+	pyproject := filepath.Join(p.Dir, "pyproject.toml")
+	if p.Before != "" {
+		if err := os.WriteFile(pyproject+backupSuffix, []byte(p.Before), 0644); err != nil {
+			return err
+		}
+	}
+	for _, name := range p.Retire {
+		path := filepath.Join(p.Dir, name)
+		if err := os.Rename(path, path+backupSuffix); err != nil {
+			return errors.Join(err, p.Restore())
+		}
+	}
+	if err := os.WriteFile(pyproject, []byte(p.After), 0644); err != nil {
+		return errors.Join(err, p.Restore())
+	}
+	return nil
+}
+
+// Restore puts the original files back after a failed or unwanted migration
+func (p *Plan) Restore() error {
+	var errs []error
+	pyproject := filepath.Join(p.Dir, "pyproject.toml")
+	if p.Before != "" {
+		errs = append(errs, os.WriteFile(pyproject, []byte(p.Before), 0644))
+		errs = append(errs, removeIfExists(pyproject+backupSuffix))
+	} else {
+		errs = append(errs, removeIfExists(pyproject))
+	}
+	for _, name := range p.Retire {
+		path := filepath.Join(p.Dir, name)
+		if _, err := os.Stat(path + backupSuffix); err == nil {
+			errs = append(errs, os.Rename(path+backupSuffix, path))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// removeIfExists deletes a file, ignoring one that is already gone
+func removeIfExists(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,512 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// personPattern splits a Poetry author string such as "Ada Lovelace <ada@example.com>"
+var personPattern = regexp.MustCompile(`^(.*?)\s*<([^>]+)>$`)
+
+// converter collects what a conversion produces besides the main tables
+type converter struct {
+	sources map[string]string
+	notes   []string
+}
+
+// note records something the user has to look at after migrating
+func (c *converter) note(format string, args ...any) {
+	c.notes = append(c.notes, fmt.Sprintf(format, args...))
+}
+
+// fromPoetry converts [tool.poetry] metadata, dependencies and groups into PEP 621 tables
+func (p *Plan) fromPoetry() error {
+	var doc map[string]any
+	if err := toml.Unmarshal([]byte(p.Before), &doc); err != nil {
+		return fmt.Errorf("reading pyproject.toml: %w", err)
+	}
+	poetry := table(doc, "tool", "poetry")
+	if poetry == nil {
+		return errors.New("pyproject.toml has no [tool.poetry] table")
+	}
+	if table(doc, "dependency-groups") != nil {
+		return errors.New("pyproject.toml already has [dependency-groups], merge Poetry's groups into it by hand")
+	}
+
+	c := &converter{sources: make(map[string]string)}
+	var out document
+
+	// Poetry 2 projects already have a [project] table, so only their groups need moving
+	hasProject := table(doc, "project") != nil
+	text := p.Before
+	if hasProject {
+		if deps := table(poetry, "dependencies"); len(deps) > 1 {
+			c.note("[tool.poetry.dependencies] was left in place; fold its constraints into [project] by hand")
+		}
+	} else {
+		c.project(&out, poetry, filepath.Base(p.Dir))
+	}
+
+	if groups := c.groups(poetry); len(groups) > 0 {
+		out.table("dependency-groups")
+		for _, name := range slices.Sorted(maps.Keys(groups)) {
+			out.set(name, tomlArray(groups[name]))
+		}
+	}
+	if len(c.sources) > 0 {
+		out.table("tool.uv.sources")
+		for _, name := range slices.Sorted(maps.Keys(c.sources)) {
+			out.set(name, c.sources[name])
+		}
+	}
+	c.indexes(&out, poetry)
+	if !hasProject {
+		text = c.buildSystem(&out, doc, poetry, text)
+	}
+
+	text, at := removeTables(text, func(name string) bool {
+		if hasProject {
+			return name == "tool.poetry.dev-dependencies" || strings.HasPrefix(name, "tool.poetry.group.")
+		}
+		return name == "tool.poetry" || strings.HasPrefix(name, "tool.poetry.")
+	})
+	p.After = insertAt(text, at, out.String())
+
+	if _, err := os.Stat(filepath.Join(p.Dir, "poetry.lock")); err == nil {
+		p.Retire = append(p.Retire, "poetry.lock")
+	}
+	p.Notes = c.notes
+	return nil
+}
+
+// project writes [project] and its sub-tables from Poetry's metadata
+func (c *converter) project(out *document, poetry map[string]any, dirName string) {
+	deps := table(poetry, "dependencies")
+
+	out.table("project")
+	name := str(poetry["name"])
+	if name == "" {
+		name = dirName
+	}
+	out.set("name", tomlString(name))
+	if version := str(poetry["version"]); version != "" {
+		out.set("version", tomlString(version))
+	}
+	if description := str(poetry["description"]); description != "" {
+		out.set("description", tomlString(description))
+	}
+	if authors := people(strs(poetry["authors"])); authors != "" {
+		out.set("authors", authors)
+	}
+	if maintainers := people(strs(poetry["maintainers"])); maintainers != "" {
+		out.set("maintainers", maintainers)
+	}
+	if readme := strs(poetry["readme"]); len(readme) > 0 {
+		out.set("readme", tomlString(readme[0]))
+		if len(readme) > 1 {
+			c.note("Only the first readme file (%s) was kept", readme[0])
+		}
+	}
+	if license := str(poetry["license"]); license != "" {
+		out.set("license", tomlInline(field{"text", tomlString(license)}))
+	}
+	if python := str(deps["python"]); python != "" {
+		if specifier, err := poetryConstraint(python); err == nil {
+			out.set("requires-python", tomlString(specifier))
+		} else {
+			c.note("python: %v", err)
+		}
+	}
+	if keywords := strs(poetry["keywords"]); len(keywords) > 0 {
+		out.set("keywords", tomlArray(keywords))
+	}
+	if classifiers := strs(poetry["classifiers"]); len(classifiers) > 0 {
+		out.set("classifiers", tomlArray(classifiers))
+	}
+
+	// Optional dependencies only belong to the extras that name them
+	var required []string
+	optional := make(map[string][]string)
+	for _, dep := range slices.Sorted(maps.Keys(deps)) {
+		if dep == "python" {
+			continue
+		}
+		lines, isOptional := c.requirement(dep, deps[dep])
+		if isOptional {
+			optional[dep] = lines
+		} else {
+			required = append(required, lines...)
+		}
+	}
+	out.set("dependencies", tomlArray(required))
+
+	if extras := table(poetry, "extras"); len(extras) > 0 {
+		out.table("project.optional-dependencies")
+		for _, extra := range slices.Sorted(maps.Keys(extras)) {
+			var lines []string
+			for _, dep := range strs(extras[extra]) {
+				if found, ok := optional[dep]; ok {
+					lines = append(lines, found...)
+				} else {
+					lines = append(lines, dep)
+				}
+			}
+			out.set(extra, tomlArray(lines))
+		}
+	}
+
+	if scripts := table(poetry, "scripts"); len(scripts) > 0 {
+		out.table("project.scripts")
+		for _, script := range slices.Sorted(maps.Keys(scripts)) {
+			if target := str(scripts[script]); target != "" {
+				out.set(script, tomlString(target))
+			} else {
+				c.note("Script %q is not a plain module:function reference and was left out", script)
+			}
+		}
+	}
+
+	urls := make(map[string]string)
+	for key, label := range map[string]string{"homepage": "Homepage", "repository": "Repository", "documentation": "Documentation"} {
+		if url := str(poetry[key]); url != "" {
+			urls[label] = url
+		}
+	}
+	for label, url := range table(poetry, "urls") {
+		urls[label] = str(url)
+	}
+	if len(urls) > 0 {
+		out.table("project.urls")
+		for _, label := range slices.Sorted(maps.Keys(urls)) {
+			out.set(label, tomlString(urls[label]))
+		}
+	}
+
+	if _, ok := poetry["plugins"]; ok {
+		c.note("[tool.poetry.plugins] entry points were not converted, add them to [project.entry-points]")
+	}
+}
+
+// buildSystem replaces a poetry-core build backend, returning the file text without the old [build-system]
+func (c *converter) buildSystem(out *document, doc, poetry map[string]any, text string) string {
+	backend := str(table(doc, "build-system")["build-backend"])
+	if !strings.HasPrefix(backend, "poetry") {
+		return text
+	}
+	text, _ = removeTables(text, func(name string) bool { return name == "build-system" })
+
+	// Projects that are not packaged don't need a build backend at all
+	if mode, ok := poetry["package-mode"].(bool); ok && !mode {
+		return text
+	}
+	out.table("build-system")
+	out.set("requires", tomlArray([]string{"hatchling"}))
+	out.set("build-backend", tomlString("hatchling.build"))
+	if _, ok := poetry["packages"]; ok {
+		c.note("The build backend changed from poetry-core to hatchling; move the packages setting to [tool.hatch.build]")
+	} else {
+		c.note("The build backend changed from poetry-core to hatchling")
+	}
+	return text
+}
+
+// groups collects Poetry's dev-dependencies and dependency groups as PEP 735 groups
+func (c *converter) groups(poetry map[string]any) map[string][]string {
+	groups := make(map[string][]string)
+	add := func(group string, deps map[string]any) {
+		for _, dep := range slices.Sorted(maps.Keys(deps)) {
+			lines, _ := c.requirement(dep, deps[dep])
+			groups[group] = append(groups[group], lines...)
+		}
+	}
+	add("dev", table(poetry, "dev-dependencies"))
+	for name, group := range table(poetry, "group") {
+		group, _ := group.(map[string]any)
+		add(name, table(group, "dependencies"))
+	}
+	for name, lines := range groups {
+		if len(lines) == 0 {
+			delete(groups, name)
+		}
+	}
+	return groups
+}
+
+// indexes converts Poetry package sources into uv indexes
+func (c *converter) indexes(out *document, poetry map[string]any) {
+	sources, _ := poetry["source"].([]any)
+	for _, source := range sources {
+		source, _ := source.(map[string]any)
+		name, url := str(source["name"]), str(source["url"])
+		if name == "" || url == "" {
+			continue
+		}
+		out.arrayTable("tool.uv.index")
+		out.set("name", tomlString(name))
+		out.set("url", tomlString(url))
+		switch priority := str(source["priority"]); {
+		case priority == "explicit":
+			out.set("explicit", "true")
+		case priority == "default", source["default"] == true:
+			out.set("default", "true")
+		}
+	}
+}
+
+// requirement converts one Poetry dependency into PEP 508 lines, recording any uv source it needs.
+// A dependency with several constraints, e.g. per Python version, becomes several lines.
+func (c *converter) requirement(name string, spec any) (lines []string, optional bool) {
+	switch spec := spec.(type) {
+	case string:
+		return []string{name + c.specifier(name, spec)}, false
+	case map[string]any:
+		line, optional := c.requirementTable(name, spec)
+		return []string{line}, optional
+	case []any:
+		for _, alternative := range spec {
+			if alternative, ok := alternative.(map[string]any); ok {
+				line, isOptional := c.requirementTable(name, alternative)
+				lines = append(lines, line)
+				optional = optional || isOptional
+			}
+		}
+		return lines, optional
+	default:
+		c.note("%s: unrecognised dependency specification, added without a version", name)
+		return []string{name}, false
+	}
+}
+
+// requirementTable converts a Poetry dependency written as a table
+func (c *converter) requirementTable(name string, spec map[string]any) (string, bool) {
+	requirement := name
+	if extras := strs(spec["extras"]); len(extras) > 0 {
+		requirement += "[" + strings.Join(extras, ",") + "]"
+	}
+
+	var markers []string
+	if python := str(spec["python"]); python != "" {
+		if marker, err := pythonMarker(python); err == nil {
+			markers = append(markers, marker)
+		} else {
+			c.note("%s: %v", name, err)
+		}
+	}
+	if marker := str(spec["markers"]); marker != "" {
+		markers = append(markers, marker)
+	}
+
+	switch {
+	case spec["git"] != nil:
+		fields := []field{{"git", tomlString(str(spec["git"]))}}
+		for _, key := range []string{"branch", "tag", "rev", "subdirectory"} {
+			if value := str(spec[key]); value != "" {
+				fields = append(fields, field{key, tomlString(value)})
+			}
+		}
+		c.source(name, tomlInline(fields...))
+	case spec["path"] != nil:
+		fields := []field{{"path", tomlString(str(spec["path"]))}}
+		if spec["develop"] == true {
+			fields = append(fields, field{"editable", "true"})
+		}
+		c.source(name, tomlInline(fields...))
+	case spec["url"] != nil:
+		requirement += " @ " + str(spec["url"])
+		if len(markers) > 0 {
+			requirement += " "
+		}
+	default:
+		if index := str(spec["source"]); index != "" {
+			c.source(name, tomlInline(field{"index", tomlString(index)}))
+		}
+		requirement += c.specifier(name, str(spec["version"]))
+	}
+
+	if len(markers) > 0 {
+		for i, marker := range markers {
+			if len(markers) > 1 && strings.Contains(marker, " or ") {
+				markers[i] = "(" + marker + ")"
+			}
+		}
+		requirement += "; " + strings.Join(markers, " and ")
+	}
+	optional, _ := spec["optional"].(bool)
+	return requirement, optional
+}
+
+// source records where uv should fetch a dependency from
+func (c *converter) source(name, value string) {
+	if _, ok := c.sources[name]; ok {
+		c.note("%s has a different source per constraint; only the last one was kept in [tool.uv.sources]", name)
+	}
+	c.sources[name] = value
+}
+
+// specifier converts a Poetry constraint, leaving the dependency unconstrained if it can't be converted
+func (c *converter) specifier(name, constraint string) string {
+	specifier, err := poetryConstraint(constraint)
+	if err != nil {
+		c.note("%s: %v, added without a version", name, err)
+		return ""
+	}
+	return specifier
+}
+
+// operatorSpace matches the optional space Poetry allows after a comparison operator
+var operatorSpace = regexp.MustCompile(`([<>=!~^]=?)\s+`)
+
+// poetryConstraint converts a Poetry version constraint such as "^1.2" into a PEP 440 specifier set
+func poetryConstraint(constraint string) (string, error) {
+	constraint = strings.TrimSpace(constraint)
+	if strings.Contains(constraint, "||") || strings.Contains(constraint, " | ") {
+		return "", fmt.Errorf("alternative constraints %q can't be expressed in PEP 440", constraint)
+	}
+	constraint = operatorSpace.ReplaceAllString(constraint, "$1")
+
+	var specifiers []string
+	for _, part := range strings.FieldsFunc(constraint, func(r rune) bool { return r == ',' || r == ' ' }) {
+		switch {
+		case part == "*":
+		case strings.HasPrefix(part, "^"):
+			specifiers = append(specifiers, ">="+part[1:], "<"+bump(part[1:], caretIndex(part[1:])))
+		case strings.HasPrefix(part, "~="):
+			specifiers = append(specifiers, part)
+		case strings.HasPrefix(part, "~"):
+			version := part[1:]
+			specifiers = append(specifiers, ">="+version, "<"+bump(version, min(1, len(strings.Split(version, "."))-1)))
+		case strings.HasPrefix(part, ">"), strings.HasPrefix(part, "<"), strings.HasPrefix(part, "!="), strings.HasPrefix(part, "=="):
+			specifiers = append(specifiers, part)
+		case strings.HasPrefix(part, "="):
+			specifiers = append(specifiers, "="+part)
+		default:
+			specifiers = append(specifiers, "=="+part)
+		}
+	}
+	return strings.Join(specifiers, ","), nil
+}
+
+// caretIndex returns the release segment a caret constraint may not change: the first non-zero one
+func caretIndex(version string) int {
+	parts := strings.Split(version, ".")
+	for i, part := range parts {
+		if leadingNumber(part) != 0 {
+			return i
+		}
+	}
+	return len(parts) - 1
+}
+
+// bump returns the upper bound for a version by incrementing the segment at index and zeroing the rest
+func bump(version string, index int) string {
+	parts := strings.Split(version, ".")
+	upper := make([]string, len(parts))
+	for i := range parts {
+		switch {
+		case i < index:
+			upper[i] = strconv.Itoa(leadingNumber(parts[i]))
+		case i == index:
+			upper[i] = strconv.Itoa(leadingNumber(parts[i]) + 1)
+		default:
+			upper[i] = "0"
+		}
+	}
+	return strings.Join(upper, ".")
+}
+
+// leadingNumber reads the number at the start of a version segment, e.g. 3 from "3rc1"
+func leadingNumber(part string) int {
+	end := strings.IndexFunc(part, func(r rune) bool { return r < '0' || r > '9' })
+	if end < 0 {
+		end = len(part)
+	}
+	n, _ := strconv.Atoi(part[:end])
+	return n
+}
+
+// pythonMarker turns a Poetry python constraint into an environment marker
+func pythonMarker(constraint string) (string, error) {
+	specifier, err := poetryConstraint(constraint)
+	if err != nil {
+		return "", err
+	}
+	var markers []string
+	for _, spec := range strings.Split(specifier, ",") {
+		if spec == "" {
+			continue
+		}
+		end := strings.IndexFunc(spec, func(r rune) bool { return !strings.ContainsRune("<>=!~", r) })
+		if end < 0 {
+			continue
+		}
+		op, version := spec[:end], strings.TrimSuffix(spec[end:], ".*")
+		variable := "python_version"
+		if strings.Count(version, ".") >= 2 {
+			variable = "python_full_version"
+		}
+		markers = append(markers, fmt.Sprintf("%s %s %s", variable, op, strconv.Quote(version)))
+	}
+	return strings.Join(markers, " and "), nil
+}
+
+// people converts Poetry "Name <email>" strings into a list of PEP 621 inline tables
+func people(entries []string) string {
+	if len(entries) == 0 {
+		return ""
+	}
+	var tables []string
+	for _, entry := range entries {
+		if match := personPattern.FindStringSubmatch(entry); match != nil && match[1] != "" {
+			tables = append(tables, tomlInline(field{"name", tomlString(match[1])}, field{"email", tomlString(match[2])}))
+		} else if match != nil {
+			tables = append(tables, tomlInline(field{"email", tomlString(match[2])}))
+		} else {
+			tables = append(tables, tomlInline(field{"name", tomlString(entry)}))
+		}
+	}
+	return "[" + strings.Join(tables, ", ") + "]"
+}
+
+// table returns a nested table from parsed TOML, or nil if it isn't there
+func table(doc map[string]any, path ...string) map[string]any {
+	for _, name := range path {
+		next, ok := doc[name].(map[string]any)
+		if !ok {
+			return nil
+		}
+		doc = next
+	}
+	return doc
+}
+
+// str returns a parsed TOML value as a string, or "" if it isn't one
+func str(value any) string {
+	s, _ := value.(string)
+	return s
+}
+
+// strs returns a parsed TOML string or list of strings as a slice
+func strs(value any) []string {
+	switch value := value.(type) {
+	case string:
+		return []string{value}
+	case []any:
+		var out []string
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package migrate
+
+import "testing"
+
+func TestPoetryConstraint(t *testing.T) {
+	tests := []struct {
+		constraint string
+		want       string
+		wantErr    bool
+	}{
+		{constraint: "^1.2.3", want: ">=1.2.3,<2.0.0"},
+		{constraint: "^0.2.3", want: ">=0.2.3,<0.3.0"},
+		{constraint: "^0.0.3", want: ">=0.0.3,<0.0.4"},
+		{constraint: "^0", want: ">=0,<1"},
+		{constraint: "^2.0rc1", want: ">=2.0rc1,<3.0"},
+		{constraint: "~1.2.3", want: ">=1.2.3,<1.3.0"},
+		{constraint: "~1", want: ">=1,<2"},
+		{constraint: "~=1.2", want: "~=1.2"},
+		{constraint: ">= 1.0, < 2.0", want: ">=1.0,<2.0"},
+		{constraint: ">=1.0 <2.0", want: ">=1.0,<2.0"},
+		{constraint: "!=1.5", want: "!=1.5"},
+		{constraint: "1.2.3", want: "==1.2.3"},
+		{constraint: "=1.2", want: "==1.2"},
+		{constraint: "==1.2", want: "==1.2"},
+		{constraint: "*", want: ""},
+		{constraint: "  ^1.0  ", want: ">=1.0,<2.0"},
+		{constraint: "^1.0 || ^2.0", wantErr: true},
+		{constraint: "1.0 | 2.0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			got, err := poetryConstraint(tt.constraint)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("poetryConstraint(%q) error = %v, want error %v", tt.constraint, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("poetryConstraint(%q) = %q, want %q", tt.constraint, got, tt.want)
+			}
+		})
+	}
+}
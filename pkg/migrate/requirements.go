@@ -0,0 +1,194 @@
+package migrate
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// requirementFiles are the files a requirements.txt project keeps its dependencies in
+var requirementFiles = []string{"requirements*.txt", filepath.Join("requirements", "*.txt")}
+
+// mainRequirements are the file names holding a project's own dependencies rather than a group's
+var mainRequirements = []string{"requirements.txt", "base.txt", "main.txt"}
+
+// eggFragment finds the package name in a VCS or URL requirement, e.g. #egg=name
+var eggFragment = regexp.MustCompile(`#egg=([A-Za-z0-9._-]+)`)
+
+// unsafeName matches characters that aren't allowed in a project name
+var unsafeName = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// fromRequirements builds [project] and [dependency-groups] from the project's requirements files
+func (p *Plan) fromRequirements() error {
+	var doc map[string]any
+	if err := toml.Unmarshal([]byte(p.Before), &doc); err != nil {
+		return fmt.Errorf("reading pyproject.toml: %w", err)
+	}
+	if table(doc, "project") != nil {
+		return errors.New("pyproject.toml already has a [project] table")
+	}
+
+	var files []string
+	for _, pattern := range requirementFiles {
+		matches, _ := filepath.Glob(filepath.Join(p.Dir, pattern))
+		for _, match := range matches {
+			rel, _ := filepath.Rel(p.Dir, match)
+			files = append(files, rel)
+		}
+	}
+	if len(files) == 0 {
+		return errors.New("no requirements files found")
+	}
+
+	c := &converter{sources: make(map[string]string)}
+	var dependencies []string
+	groups := make(map[string][]string)
+	for _, file := range files {
+		lines, err := c.readRequirements(p.Dir, file, files)
+		if err != nil {
+			return err
+		}
+		if group := groupName(file); group == "" {
+			dependencies = appendNew(dependencies, lines...)
+		} else {
+			groups[group] = appendNew(groups[group], lines...)
+		}
+	}
+	if len(dependencies) > 0 && !slices.ContainsFunc(dependencies, func(line string) bool { return !strings.Contains(line, "==") }) {
+		c.note("Every dependency is pinned with ==; uv.lock now records exact versions, so these can be loosened")
+	}
+
+	var out document
+	out.table("project")
+	out.set("name", tomlString(projectName(p.Dir)))
+	out.set("version", tomlString("0.1.0"))
+	if _, err := os.Stat(filepath.Join(p.Dir, "README.md")); err == nil {
+		out.set("readme", tomlString("README.md"))
+	}
+	if data, err := os.ReadFile(filepath.Join(p.Dir, ".python-version")); err == nil {
+		if version := strings.TrimSpace(string(data)); version != "" {
+			out.set("requires-python", tomlString(">="+version))
+		}
+	}
+	out.set("dependencies", tomlArray(dependencies))
+
+	if len(groups) > 0 {
+		out.table("dependency-groups")
+		for _, name := range slices.Sorted(maps.Keys(groups)) {
+			out.set(name, tomlArray(groups[name]))
+		}
+	}
+
+	// Existing tool settings stay below the new tables
+	p.After = insertAt(p.Before, 0, out.String())
+	p.Retire = files
+	p.Notes = c.notes
+	return nil
+}
+
+// readRequirements reads the PEP 508 lines of a requirements file, noting anything it had to skip.
+// Includes of other files being converted are dropped, since those files are converted themselves.
+func (c *converter) readRequirements(dir, file string, converting []string) ([]string, error) {
+	f, err := os.Open(filepath.Join(dir, file))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []string
+	var pending string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := pending + scanner.Text()
+		pending = ""
+		if strings.HasSuffix(line, `\`) {
+			pending = strings.TrimSuffix(line, `\`) + " "
+			continue
+		}
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if option, value, ok := requirementOption(line); ok {
+			include := filepath.Join(filepath.Dir(file), value)
+			if (option == "-r" || option == "--requirement") && slices.Contains(converting, include) {
+				continue
+			}
+			c.note("%s: skipped %q", file, line)
+			continue
+		}
+
+		// Per-requirement options such as --hash have no place in pyproject.toml
+		if i := strings.Index(line, " --"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		if strings.Contains(line, "://") && !strings.Contains(line, " @ ") {
+			match := eggFragment.FindStringSubmatch(line)
+			if match == nil {
+				c.note("%s: skipped %q, add it with a package name as name @ url", file, line)
+				continue
+			}
+			line = match[1] + " @ " + eggFragment.ReplaceAllString(line, "")
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// requirementOption splits a pip option line such as "-r base.txt" into the option and its value
+func requirementOption(line string) (option, value string, ok bool) {
+	if !strings.HasPrefix(line, "-") {
+		return "", "", false
+	}
+	option, value, _ = strings.Cut(line, " ")
+	if name, inline, found := strings.Cut(option, "="); found {
+		option, value = name, inline
+	}
+	return option, strings.TrimSpace(value), true
+}
+
+// groupName returns the dependency group a requirements file belongs to, or "" for the main dependencies
+func groupName(file string) string {
+	base := filepath.Base(file)
+	if slices.Contains(mainRequirements, base) {
+		return ""
+	}
+	name := strings.TrimSuffix(base, ".txt")
+	name = strings.TrimPrefix(name, "requirements")
+	name = strings.Trim(name, "-_.")
+	if name == "" {
+		return ""
+	}
+	return strings.ToLower(name)
+}
+
+// projectName derives a valid project name from a directory name
+func projectName(dir string) string {
+	name := unsafeName.ReplaceAllString(strings.ToLower(filepath.Base(dir)), "-")
+	if name = strings.Trim(name, "-._"); name == "" {
+		return "project"
+	}
+	return name
+}
+
+// appendNew appends lines that aren't in the list yet
+func appendNew(list []string, lines ...string) []string {
+	for _, line := range lines {
+		if !slices.Contains(list, line) {
+			list = append(list, line)
+		}
+	}
+	return list
+}
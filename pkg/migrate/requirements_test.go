@@ -0,0 +1,119 @@
+package migrate
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/chloebubble/tuv/pkg/scanner"
+	"github.com/pelletier/go-toml/v2"
+)
+
+func TestGroupName(t *testing.T) {
+	tests := []struct {
+		file string
+		want string
+	}{
+		{file: "requirements.txt", want: ""},
+		{file: filepath.Join("requirements", "base.txt"), want: ""},
+		{file: filepath.Join("requirements", "main.txt"), want: ""},
+		{file: "requirements-dev.txt", want: "dev"},
+		{file: "requirements_test.txt", want: "test"},
+		{file: "requirements.docs.txt", want: "docs"},
+		{file: "requirements-Lint.txt", want: "lint"},
+		{file: filepath.Join("requirements", "dev.txt"), want: "dev"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			if got := groupName(tt.file); got != tt.want {
+				t.Errorf("groupName(%q) = %q, want %q", tt.file, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrepareRequirementsGroups(t *testing.T) {
+	tests := []struct {
+		name         string
+		files        map[string]string
+		dependencies []string
+		groups       map[string][]string
+		notes        int
+	}{
+		{
+			name: "main and dev files",
+			files: map[string]string{
+				"requirements.txt":     "requests>=2.31\nrich  # pretty output\n",
+				"requirements-dev.txt": "-r requirements.txt\npytest==8.0\n",
+			},
+			dependencies: []string{"requests>=2.31", "rich"},
+			groups:       map[string][]string{"dev": {"pytest==8.0"}},
+		},
+		{
+			name: "requirements directory",
+			files: map[string]string{
+				filepath.Join("requirements", "base.txt"): "httpx\n",
+				filepath.Join("requirements", "test.txt"): "pytest\ncoverage --hash=sha256:abc\n",
+			},
+			dependencies: []string{"httpx"},
+			groups:       map[string][]string{"test": {"pytest", "coverage"}},
+		},
+		{
+			name: "unsupported options are noted",
+			files: map[string]string{
+				"requirements.txt": "--index-url https://example.com/simple\nflask\nhttps://example.com/pkg.tar.gz\n",
+			},
+			dependencies: []string{"flask"},
+			notes:        2,
+		},
+		{
+			name: "egg fragment becomes a named url",
+			files: map[string]string{
+				"requirements.txt": "git+https://example.com/lib.git#egg=lib\n",
+			},
+			dependencies: []string{"lib @ git+https://example.com/lib.git"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, contents := range tt.files {
+				path := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			plan, err := Prepare(dir, scanner.ToolRequirements)
+			if err != nil {
+				t.Fatalf("Prepare: %v", err)
+			}
+			var got struct {
+				Project struct {
+					Dependencies []string `toml:"dependencies"`
+				} `toml:"project"`
+				Groups map[string][]string `toml:"dependency-groups"`
+			}
+			if err := toml.Unmarshal([]byte(plan.After), &got); err != nil {
+				t.Fatalf("converted pyproject.toml doesn't parse: %v\n%s", err, plan.After)
+			}
+			if !slices.Equal(got.Project.Dependencies, tt.dependencies) {
+				t.Errorf("dependencies = %q, want %q", got.Project.Dependencies, tt.dependencies)
+			}
+			if !maps.EqualFunc(got.Groups, tt.groups, slices.Equal) {
+				t.Errorf("dependency-groups = %q, want %q", got.Groups, tt.groups)
+			}
+			if len(plan.Notes) != tt.notes {
+				t.Errorf("got notes %q, want %d", plan.Notes, tt.notes)
+			}
+			if len(plan.Retire) != len(tt.files) {
+				t.Errorf("Retire = %q, want every requirements file", plan.Retire)
+			}
+		})
+	}
+}
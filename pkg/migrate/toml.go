@@ -0,0 +1,153 @@
+package migrate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// bareKey matches keys that can be written without quotes
+var bareKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// tableHeader matches a [table] or [[array]] header line and captures its name
+var tableHeader = regexp.MustCompile(`^\s*\[\[?\s*([A-Za-z_][A-Za-z0-9_\-."' ]*?)\s*\]\]?\s*(#.*)?$`)
+
+// tomlString quotes a string as a TOML basic string
+func tomlString(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// tomlKey returns a key, quoted if it needs to be
+func tomlKey(key string) string {
+	if bareKey.MatchString(key) {
+		return key
+	}
+	return tomlString(key)
+}
+
+// tomlArray renders a list of strings, one per line once there are several
+func tomlArray(items []string) string {
+	if len(items) == 0 {
+		return "[]"
+	}
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = tomlString(item)
+	}
+	if len(items) == 1 {
+		return "[" + quoted[0] + "]"
+	}
+	return "[\n    " + strings.Join(quoted, ",\n    ") + ",\n]"
+}
+
+// field is one key of an inline table, with its value already rendered
+type field struct {
+	key   string
+	value string
+}
+
+// tomlInline renders an inline table
+func tomlInline(fields ...field) string {
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		parts[i] = tomlKey(f.key) + " = " + f.value
+	}
+	return "{ " + strings.Join(parts, ", ") + " }"
+}
+
+// document builds a TOML file section by section, keeping the order things are added in
+type document struct {
+	b strings.Builder
+}
+
+// table starts a [name] section
+func (d *document) table(name string) {
+	if d.b.Len() > 0 {
+		d.b.WriteString("\n")
+	}
+	fmt.Fprintf(&d.b, "[%s]\n", name)
+}
+
+// arrayTable starts a [[name]] section
+func (d *document) arrayTable(name string) {
+	if d.b.Len() > 0 {
+		d.b.WriteString("\n")
+	}
+	fmt.Fprintf(&d.b, "[[%s]]\n", name)
+}
+
+// set writes a key with an already rendered value
+func (d *document) set(key, value string) {
+	fmt.Fprintf(&d.b, "%s = %s\n", tomlKey(key), value)
+}
+
+// String returns the document's text
+func (d *document) String() string {
+	return d.b.String()
+}
+
+// tableName normalises a header's name so it can be compared, e.g. `tool . "poetry"` becomes tool.poetry
+func tableName(raw string) string {
+	return strings.NewReplacer(" ", "", `"`, "", "'", "").Replace(raw)
+}
+
+// removeTables drops every table whose name matches from a TOML file, keeping everything else as written.
+// It returns the remaining text and the line where the first dropped table started, or -1.
+func removeTables(text string, drop func(name string) bool) (string, int) {
+	var kept []string
+	at := -1
+	dropping := false
+	for _, line := range strings.Split(text, "\n") {
+		if match := tableHeader.FindStringSubmatch(line); match != nil {
+			dropping = drop(tableName(match[1]))
+			if dropping && at < 0 {
+				at = len(kept)
+			}
+		}
+		if !dropping {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n"), at
+}
+
+// insertAt puts a block of text at a line of a file, separated from its neighbours by blank lines.
+// A negative line appends it.
+func insertAt(text string, line int, block string) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	if strings.TrimSpace(text) == "" {
+		lines = nil
+	}
+	if line < 0 || line > len(lines) {
+		line = len(lines)
+	}
+	before := trimBlank(lines[:line])
+	after := trimBlank(lines[line:])
+
+	var parts []string
+	if len(before) > 0 {
+		parts = append(parts, strings.Join(before, "\n"))
+	}
+	parts = append(parts, strings.TrimRight(block, "\n"))
+	if len(after) > 0 {
+		parts = append(parts, strings.Join(after, "\n"))
+	}
+	return strings.Join(parts, "\n\n") + "\n"
+}
+
+// trimBlank drops blank lines from both ends of a slice of lines
+func trimBlank(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
@@ -0,0 +1,131 @@
+package pyversion
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// pep440 matches a version in any spelling PEP 440 accepts, from its appendix
+var pep440 = regexp.MustCompile(`^v?(?:(?P<epoch>[0-9]+)!)?(?P<release>[0-9]+(?:\.[0-9]+)*)` +
+	`(?:[-_.]?(?P<pre_l>alpha|a|beta|b|preview|pre|c|rc)[-_.]?(?P<pre_n>[0-9]+)?)?` +
+	`(?:-(?P<post_n1>[0-9]+)|[-_.]?(?P<post_l>post|rev|r)[-_.]?(?P<post_n2>[0-9]+)?)?` +
+	`(?:[-_.]?(?P<dev_l>dev)[-_.]?(?P<dev_n>[0-9]+)?)?` +
+	`(?:\+(?P<local>[a-z0-9]+(?:[-_.][a-z0-9]+)*))?$`)
+
+// PackageVersion is a full PEP 440 version of a package, including pre, post and dev releases,
+// such as 2.0.0rc1 or 1!1.4.post2.dev3
+type PackageVersion struct {
+	Epoch   int
+	Release []int
+	// Pre is the pre-release phase (0 alpha, 1 beta, 2 release candidate) and number, or nil
+	Pre   *[2]int
+	Post  int // -1 if not a post-release
+	Dev   int // -1 if not a dev release
+	Local string
+}
+
+// ParsePackageVersion reads a PEP 440 version
+func ParsePackageVersion(s string) (PackageVersion, error) {
+	match := pep440.FindStringSubmatch(strings.ToLower(strings.TrimSpace(s)))
+	if match == nil {
+		return PackageVersion{}, fmt.Errorf("%q is not a PEP 440 version", s)
+	}
+	group := func(name string) string { return match[pep440.SubexpIndex(name)] }
+	number := func(text string) int {
+		n, _ := strconv.Atoi(text)
+		return n
+	}
+
+	v := PackageVersion{Epoch: number(group("epoch")), Post: -1, Dev: -1, Local: group("local")}
+	for _, part := range strings.Split(group("release"), ".") {
+		v.Release = append(v.Release, number(part))
+	}
+	if label := group("pre_l"); label != "" {
+		phase := 2
+		switch label {
+		case "a", "alpha":
+			phase = 0
+		case "b", "beta":
+			phase = 1
+		}
+		v.Pre = &[2]int{phase, number(group("pre_n"))}
+	}
+	if n := group("post_n1"); n != "" {
+		v.Post = number(n)
+	} else if group("post_l") != "" {
+		v.Post = number(group("post_n2"))
+	}
+	if group("dev_l") != "" {
+		v.Dev = number(group("dev_n"))
+	}
+	return v, nil
+}
+
+// Compare returns -1, 0 or 1 as v sorts before, equal to or after other in PEP 440 order
+func (v PackageVersion) Compare(other PackageVersion) int {
+	if c := cmp.Compare(v.Epoch, other.Epoch); c != 0 {
+		return c
+	}
+	for i := range max(len(v.Release), len(other.Release)) {
+		if c := cmp.Compare(segmentOf(v.Release, i), segmentOf(other.Release, i)); c != 0 {
+			return c
+		}
+	}
+	if c := slices.Compare(v.preKey(), other.preKey()); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(v.Post, other.Post); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(v.devKey(), other.devKey()); c != 0 {
+		return c
+	}
+	return cmp.Compare(v.Local, other.Local)
+}
+
+// preKey orders the pre-release part: a dev release of a final version comes before its
+// pre-releases, which come before the final release
+func (v PackageVersion) preKey() []int {
+	switch {
+	case v.Pre != nil:
+		return []int{v.Pre[0], v.Pre[1]}
+	case v.Post < 0 && v.Dev >= 0:
+		return []int{math.MinInt}
+	default:
+		return []int{math.MaxInt}
+	}
+}
+
+// devKey orders dev releases before the release they lead up to
+func (v PackageVersion) devKey() int {
+	if v.Dev < 0 {
+		return math.MaxInt
+	}
+	return v.Dev
+}
+
+// segmentOf returns the nth release segment, treating missing segments as zero
+func segmentOf(release []int, n int) int {
+	if n < len(release) {
+		return release[n]
+	}
+	return 0
+}
+
+// ComparePackageVersions parses and compares two PEP 440 versions
+func ComparePackageVersions(a, b string) (int, error) {
+	va, err := ParsePackageVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := ParsePackageVersion(b)
+	if err != nil {
+		return 0, err
+	}
+	return va.Compare(vb), nil
+}
@@ -0,0 +1,125 @@
+package pyversion
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Version is a Python release number such as 3.12 or 3.12.4. Pre-release and build suffixes are dropped.
+type Version struct {
+	Parts []int
+}
+
+// Parse reads a version number, ignoring anything after the numeric release segments
+func Parse(s string) (Version, error) {
+	s = strings.TrimSpace(s)
+	var v Version
+	for _, part := range strings.Split(s, ".") {
+		end := strings.IndexFunc(part, func(r rune) bool { return r < '0' || r > '9' })
+		digits := part
+		if end >= 0 {
+			digits = part[:end]
+		}
+		n, err := strconv.Atoi(digits)
+		if err != nil {
+			break
+		}
+		v.Parts = append(v.Parts, n)
+		if end >= 0 {
+			break
+		}
+	}
+	if len(v.Parts) == 0 {
+		return Version{}, fmt.Errorf("%q is not a version", s)
+	}
+	return v, nil
+}
+
+// ParsePin reads the version requested by a .python-version file, which may name an implementation,
+// e.g. "cpython-3.12" or "pypy@3.10"
+func ParsePin(s string) (Version, error) {
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if i := strings.IndexAny(line, "0123456789"); i > 0 {
+			line = line[i:]
+		}
+		return Parse(line)
+	}
+	return Version{}, fmt.Errorf("no version in %q", s)
+}
+
+// String formats the version as dotted numbers
+func (v Version) String() string {
+	parts := make([]string, len(v.Parts))
+	for i, n := range v.Parts {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ".")
+}
+
+// segment returns the nth release segment, treating missing segments as zero
+func (v Version) segment(n int) int {
+	if n < len(v.Parts) {
+		return v.Parts[n]
+	}
+	return 0
+}
+
+// Compare returns -1, 0 or 1 as v is older than, equal to or newer than other
+func (v Version) Compare(other Version) int {
+	for i := range max(len(v.Parts), len(other.Parts)) {
+		a, b := v.segment(i), other.segment(i)
+		if a != b {
+			if a < b {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// Minor returns the major.minor release the version belongs to, e.g. 3.12 for 3.12.4
+func (v Version) Minor() Version {
+	return Version{Parts: []int{v.segment(0), v.segment(1)}}
+}
+
+// Matches reports whether a more precise version, such as a venv's 3.12.4, fits a pin such as 3.12
+func (v Version) Matches(actual Version) bool {
+	for i, n := range v.Parts {
+		if actual.segment(i) != n {
+			return false
+		}
+	}
+	return true
+}
+
+// endOfLife lists when each CPython minor release stops getting security fixes
+var endOfLife = map[string]string{
+	"2.7":  "2020-01-01",
+	"3.5":  "2020-09-30",
+	"3.6":  "2021-12-23",
+	"3.7":  "2023-06-27",
+	"3.8":  "2024-10-07",
+	"3.9":  "2025-10-31",
+	"3.10": "2026-10-31",
+	"3.11": "2027-10-31",
+	"3.12": "2028-10-31",
+	"3.13": "2029-10-31",
+	"3.14": "2030-10-31",
+}
+
+// EndOfLife returns when the version's minor release stops being supported, if it is known
+func EndOfLife(v Version) (time.Time, bool) {
+	date, ok := endOfLife[v.Minor().String()]
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse("2006-01-02", date)
+	return t, err == nil
+}
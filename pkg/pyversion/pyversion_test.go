@@ -0,0 +1,37 @@
+package pyversion
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParsePin(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		want    []int
+		wantErr bool
+	}{
+		{name: "minor", file: "3.12\n", want: []int{3, 12}},
+		{name: "patch", file: "3.11.4", want: []int{3, 11, 4}},
+		{name: "implementation prefix", file: "cpython-3.12", want: []int{3, 12}},
+		{name: "implementation at", file: "pypy@3.10", want: []int{3, 10}},
+		{name: "full uv request", file: "cpython-3.12.1-macos-aarch64-none", want: []int{3, 12, 1}},
+		{name: "free-threaded", file: "3.13t", want: []int{3, 13}},
+		{name: "comments and blank lines", file: "# project python\n\n  3.9  \n3.10\n", want: []int{3, 9}},
+		{name: "empty", file: "", wantErr: true},
+		{name: "only comments", file: "# nothing here\n", wantErr: true},
+		{name: "no version", file: "system", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePin(tt.file)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePin(%q) error = %v, want error %v", tt.file, err, tt.wantErr)
+			}
+			if !slices.Equal(got.Parts, tt.want) {
+				t.Errorf("ParsePin(%q) = %v, want %v", tt.file, got.Parts, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package pyversion
+
+import (
+	"fmt"
+	"strings"
+)
+
+// clause is one comparison of a specifier set, such as >=3.9
+type clause struct {
+	op       string
+	version  Version
+	wildcard bool
+}
+
+// Specifier is a PEP 440 version specifier set such as ">=3.9,<4"
+type Specifier struct {
+	clauses []clause
+	text    string
+}
+
+// operators are the PEP 440 comparison operators, longest first so prefixes match correctly
+var operators = []string{"===", "~=", "==", "!=", "<=", ">=", "<", ">"}
+
+// ParseSpecifier reads a PEP 440 specifier set
+func ParseSpecifier(s string) (Specifier, error) {
+	spec := Specifier{text: strings.TrimSpace(s)}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		op := ""
+		for _, candidate := range operators {
+			if strings.HasPrefix(part, candidate) {
+				op = candidate
+				break
+			}
+		}
+		if op == "" {
+			return Specifier{}, fmt.Errorf("%q has no comparison operator", part)
+		}
+		text := strings.TrimSpace(part[len(op):])
+		c := clause{op: op, wildcard: strings.HasSuffix(text, ".*")}
+		v, err := Parse(strings.TrimSuffix(text, ".*"))
+		if err != nil {
+			return Specifier{}, err
+		}
+		c.version = v
+		spec.clauses = append(spec.clauses, c)
+	}
+	return spec, nil
+}
+
+// String returns the specifier as written
+func (s Specifier) String() string {
+	return s.text
+}
+
+// Allows reports whether a version satisfies every clause
+func (s Specifier) Allows(v Version) bool {
+	for _, c := range s.clauses {
+		if !c.allows(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// AllowsPin reports whether any release a .python-version pin could resolve to is allowed.
+// A pin such as 3.12 covers every 3.12.x release, so both ends of that range are tried.
+func (s Specifier) AllowsPin(pin Version) bool {
+	if len(pin.Parts) >= 3 {
+		return s.Allows(pin)
+	}
+	lowest := Version{Parts: []int{pin.segment(0), pin.segment(1), 0}}
+	highest := Version{Parts: []int{pin.segment(0), pin.segment(1), 999}}
+	return s.Allows(lowest) || s.Allows(highest)
+}
+
+// allows checks a version against one clause
+func (c clause) allows(v Version) bool {
+	if c.wildcard {
+		prefix := c.version.Matches(v)
+		if c.op == "!=" {
+			return !prefix
+		}
+		return prefix
+	}
+
+	cmp := v.Compare(c.version)
+	switch c.op {
+	case "==", "===":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "~=":
+		// ~=3.9.1 means >=3.9.1 and ==3.9.*
+		if cmp < 0 || len(c.version.Parts) < 2 {
+			return cmp >= 0
+		}
+		return Version{Parts: c.version.Parts[:len(c.version.Parts)-1]}.Matches(v)
+	}
+	return false
+}
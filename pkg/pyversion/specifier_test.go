@@ -0,0 +1,43 @@
+package pyversion
+
+import "testing"
+
+func TestAllowsPin(t *testing.T) {
+	tests := []struct {
+		specifier string
+		pin       string
+		want      bool
+	}{
+		{specifier: ">=3.9", pin: "3.12", want: true},
+		{specifier: ">=3.13", pin: "3.12", want: false},
+		{specifier: "<3.12", pin: "3.12", want: false},
+		{specifier: "<3.12", pin: "3.11", want: true},
+		{specifier: ">=3.12.4", pin: "3.12", want: true},
+		{specifier: ">=3.12.4", pin: "3.12.1", want: false},
+		{specifier: ">=3.12.4", pin: "3.12.4", want: true},
+		{specifier: ">=3.9,<3.12", pin: "3.11", want: true},
+		{specifier: ">=3.9,<3.12", pin: "3.12", want: false},
+		{specifier: "==3.11.*", pin: "3.11", want: true},
+		{specifier: "==3.11.*", pin: "3.12", want: false},
+		{specifier: "!=3.12.*", pin: "3.12", want: false},
+		{specifier: "~=3.10", pin: "3.12", want: true},
+		{specifier: "~=3.10.2", pin: "3.11", want: false},
+		{specifier: "==3.12.0", pin: "3.12", want: true},
+		{specifier: "", pin: "3.12", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.specifier+" "+tt.pin, func(t *testing.T) {
+			spec, err := ParseSpecifier(tt.specifier)
+			if err != nil {
+				t.Fatalf("ParseSpecifier(%q): %v", tt.specifier, err)
+			}
+			pin, err := ParsePin(tt.pin)
+			if err != nil {
+				t.Fatalf("ParsePin(%q): %v", tt.pin, err)
+			}
+			if got := spec.AllowsPin(pin); got != tt.want {
+				t.Errorf("%q AllowsPin(%s) = %v, want %v", tt.specifier, tt.pin, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package runner
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// maxHistory is how many runs are kept per project
+const maxHistory = 50
+
+// Record is one past run of a script or task
+type Record struct {
+	Name     string        `json:"name"`
+	Command  string        `json:"command"`
+	Started  time.Time     `json:"started"`
+	Duration time.Duration `json:"duration"`
+	ExitCode int           `json:"exit_code"`
+}
+
+// History stores past runs per project path, newest first
+type History struct {
+	path string
+	Runs map[string][]Record `json:"runs"`
+}
+
+// LoadHistory reads the run history file, returning an empty history if it does not exist yet
+func LoadHistory(path string) (*History, error) {
+	h := &History{path: path, Runs: make(map[string][]Record)}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return h, nil
+	}
+	if err != nil {
+		return h, err
+	}
+	if err := json.Unmarshal(data, h); err != nil {
+		return h, err
+	}
+	if h.Runs == nil {
+		h.Runs = make(map[string][]Record)
+	}
+	return h, nil
+}
+
+// Add records a run for a project and saves the history
+func (h *History) Add(projectPath string, record Record) error {
+	runs := append([]Record{record}, h.Runs[projectPath]...)
+	if len(runs) > maxHistory {
+		runs = runs[:maxHistory]
+	}
+	h.Runs[projectPath] = runs
+	return h.save()
+}
+
+// For returns the recorded runs for a project, newest first
+func (h *History) For(projectPath string) []Record {
+	return h.Runs[projectPath]
+}
+
+// save writes the history to disk
+func (h *History) save() error {
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(h.path, data, 0644)
+}
@@ -0,0 +1,13 @@
+//go:build !unix
+
+package runner
+
+import "os/exec"
+
+// startGroup does nothing where process groups aren't available
+func startGroup(cmd *exec.Cmd) {}
+
+// killGroup kills the command's process. Its children are left to the wait delay in Start.
+func killGroup(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
@@ -0,0 +1,122 @@
+package runner
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/subosito/gotenv"
+)
+
+// waitDelay is how long a process's output is still read after it exits. A child it left
+// running that holds the output open is cut off after this, so the process can finish.
+const waitDelay = 2 * time.Second
+
+// Result is how a finished process exited
+type Result struct {
+	ExitCode int
+	Duration time.Duration
+	Err      error
+}
+
+// Process is a running command whose combined output is streamed line by line
+type Process struct {
+	cmd     *exec.Cmd
+	lines   chan string
+	done    chan Result
+	started time.Time
+}
+
+// Start runs a command in dir with the project's .env loaded on top of the current environment
+func Start(dir string, name string, args ...string) (*Process, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), DotEnv(dir)...)
+	cmd.WaitDelay = waitDelay
+	startGroup(cmd)
+
+	pr, pw := io.Pipe()
+	cmd.Stdout = pw
+	cmd.Stderr = pw
+
+	p := &Process{
+		cmd:     cmd,
+		lines:   make(chan string, 256),
+		done:    make(chan Result, 1),
+		started: time.Now(),
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	go func() {
+		scanner := bufio.NewScanner(pr)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			p.lines <- scanner.Text()
+		}
+		// Keep draining so the process never blocks on a full pipe
+		io.Copy(io.Discard, pr)
+		close(p.lines)
+	}()
+
+	go func() {
+		err := cmd.Wait()
+		pw.Close()
+		if errors.Is(err, exec.ErrWaitDelay) {
+			err = nil
+		}
+
+		result := Result{Duration: time.Since(p.started), Err: err}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			result.ExitCode = exitErr.ExitCode()
+			result.Err = nil
+		} else if err != nil {
+			result.ExitCode = -1
+		}
+		p.done <- result
+	}()
+
+	return p, nil
+}
+
+// Lines delivers output lines and is closed when the output ends
+func (p *Process) Lines() <-chan string {
+	return p.lines
+}
+
+// Done delivers the result once the process has exited
+func (p *Process) Done() <-chan Result {
+	return p.done
+}
+
+// Started returns when the process was started
+func (p *Process) Started() time.Time {
+	return p.started
+}
+
+// Kill stops the process and everything it started
+func (p *Process) Kill() error {
+	if p.cmd.Process == nil {
+		return nil
+	}
+	return killGroup(p.cmd)
+}
+
+// DotEnv returns the KEY=value pairs defined in the project's .env file, if any
+func DotEnv(dir string) []string {
+	values, err := gotenv.Read(filepath.Join(dir, ".env"))
+	if err != nil {
+		return nil
+	}
+	env := make([]string, 0, len(values))
+	for key, value := range values {
+		env = append(env, key+"="+value)
+	}
+	return env
+}
@@ -0,0 +1,136 @@
+package sbom
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+)
+
+// cdxBOM is a CycloneDX 1.5 JSON document
+type cdxBOM struct {
+	BOMFormat    string          `json:"bomFormat"`
+	SpecVersion  string          `json:"specVersion"`
+	SerialNumber string          `json:"serialNumber"`
+	Version      int             `json:"version"`
+	Metadata     cdxMetadata     `json:"metadata"`
+	Components   []cdxComponent  `json:"components"`
+	Dependencies []cdxDependency `json:"dependencies"`
+}
+
+type cdxMetadata struct {
+	Timestamp string       `json:"timestamp"`
+	Tools     cdxTools     `json:"tools"`
+	Component cdxComponent `json:"component"`
+}
+
+type cdxTools struct {
+	Components []cdxComponent `json:"components"`
+}
+
+type cdxComponent struct {
+	Type               string        `json:"type"`
+	BOMRef             string        `json:"bom-ref,omitempty"`
+	Name               string        `json:"name"`
+	Version            string        `json:"version,omitempty"`
+	PURL               string        `json:"purl,omitempty"`
+	Hashes             []cdxHash     `json:"hashes,omitempty"`
+	Licenses           []cdxLicense  `json:"licenses,omitempty"`
+	ExternalReferences []cdxExternal `json:"externalReferences,omitempty"`
+}
+
+type cdxHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+type cdxLicense struct {
+	Expression string `json:"expression"`
+}
+
+type cdxExternal struct {
+	Type   string    `json:"type"`
+	URL    string    `json:"url"`
+	Hashes []cdxHash `json:"hashes,omitempty"`
+}
+
+type cdxDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+// cdxAlgorithms maps uv.lock hash names to CycloneDX's
+var cdxAlgorithms = map[string]string{
+	"md5":    "MD5",
+	"sha1":   "SHA-1",
+	"sha256": "SHA-256",
+	"sha384": "SHA-384",
+	"sha512": "SHA-512",
+}
+
+// cdxHashes converts a hash, leaving it out if CycloneDX has no name for its algorithm
+func cdxHashes(hash *Hash) []cdxHash {
+	if hash == nil {
+		return nil
+	}
+	alg, ok := cdxAlgorithms[strings.ToLower(hash.Algorithm)]
+	if !ok {
+		return nil
+	}
+	return []cdxHash{{Alg: alg, Content: hash.Value}}
+}
+
+// cdxComponentOf converts a component. Its own hash is that of the download location, and every
+// sdist and wheel is listed as a distribution with its own hash.
+func cdxComponentOf(c Component, kind string) cdxComponent {
+	out := cdxComponent{Type: kind, BOMRef: c.Ref, Name: c.Name, Version: c.Version, PURL: c.PURL, Hashes: cdxHashes(c.Hash)}
+	if c.License != "" {
+		out.Licenses = []cdxLicense{{Expression: c.License}}
+	}
+	switch {
+	case len(c.Distributions) > 0:
+		for _, dist := range c.Distributions {
+			out.ExternalReferences = append(out.ExternalReferences, cdxExternal{Type: "distribution", URL: dist.URL, Hashes: cdxHashes(dist.Hash)})
+		}
+	case c.Download != "":
+		kind := "distribution"
+		if strings.HasPrefix(c.Download, "git+") {
+			kind = "vcs"
+		}
+		out.ExternalReferences = []cdxExternal{{Type: kind, URL: c.Download}}
+	}
+	return out
+}
+
+// writeCycloneDX encodes the BOM as CycloneDX JSON
+func (b *BOM) writeCycloneDX(w io.Writer) error {
+	doc := cdxBOM{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.5",
+		SerialNumber: "urn:uuid:" + b.Serial,
+		Version:      1,
+		Metadata: cdxMetadata{
+			Timestamp: b.Created.Format(time.RFC3339),
+			Tools:     cdxTools{Components: []cdxComponent{{Type: "application", Name: "tuv"}}},
+			Component: cdxComponentOf(b.Project, "application"),
+		},
+		Components:   []cdxComponent{},
+		Dependencies: []cdxDependency{{Ref: b.Project.Ref, DependsOn: nonNil(b.Project.DependsOn)}},
+	}
+	for _, c := range b.Components {
+		doc.Components = append(doc.Components, cdxComponentOf(c, "library"))
+		doc.Dependencies = append(doc.Dependencies, cdxDependency{Ref: c.Ref, DependsOn: nonNil(c.DependsOn)})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// nonNil returns refs, or an empty list instead of nil so it encodes as []
+func nonNil(refs []string) []string {
+	if refs == nil {
+		return []string{}
+	}
+	return refs
+}
@@ -0,0 +1,329 @@
+package sbom
+
+import (
+	"cmp"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/chloebubble/tuv/pkg/licenses"
+	"github.com/chloebubble/tuv/pkg/scanner"
+)
+
+// Format is an SBOM file format
+type Format string
+
+const (
+	CycloneDX Format = "cyclonedx"
+	SPDX      Format = "spdx"
+)
+
+// Formats lists the supported formats
+var Formats = []Format{CycloneDX, SPDX}
+
+// ParseFormat returns the format with the given name
+func ParseFormat(name string) (Format, error) {
+	format := Format(strings.ToLower(strings.TrimSpace(name)))
+	if !slices.Contains(Formats, format) {
+		return "", fmt.Errorf("unknown SBOM format %q (valid formats: cyclonedx, spdx)", name)
+	}
+	return format, nil
+}
+
+// Filename returns the file name an SBOM in the format is written to inside a project
+func (f Format) Filename() string {
+	if f == SPDX {
+		return "sbom.spdx.json"
+	}
+	return "sbom.cdx.json"
+}
+
+// Hash is a digest of one of a package's distributions
+type Hash struct {
+	// Algorithm is the lower-case name uv.lock uses, such as sha256
+	Algorithm string
+	Value     string
+}
+
+// Distribution is one downloadable file of a package, an sdist or a wheel
+type Distribution struct {
+	URL  string
+	Hash *Hash
+}
+
+// Component is the project or one of the packages its lock resolves
+type Component struct {
+	// Ref identifies the component within the BOM
+	Ref     string
+	Name    string
+	Version string
+	// PURL is the package URL, empty for local packages
+	PURL string
+	// License is the SPDX expression the component declares, if known
+	License string
+	// Download is where the component is fetched from: its sdist, else its first wheel, else its source
+	Download string
+	// Hash is the digest of the file Download points to, if uv.lock records one
+	Hash *Hash
+	// Distributions holds every sdist and wheel uv.lock lists, each with its own digest
+	Distributions []Distribution
+	Local         bool
+	// DependsOn holds the refs of the component's direct dependencies
+	DependsOn []string
+}
+
+// BOM is a project and the packages its lock resolves
+type BOM struct {
+	Project    Component
+	Components []Component
+	Created    time.Time
+	// Serial is a random UUID that identifies this BOM
+	Serial string
+}
+
+// Build reads the project's pyproject.toml and the uv.lock in lockDir. If lockDir is empty the lock
+// is looked for in the project and then its parents, where a workspace keeps it. A workspace root's
+// BOM covers the whole lock; any other project's covers the packages it depends on.
+func Build(projectPath, lockDir string) (*BOM, error) {
+	projectPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	pyproject, err := scanner.ReadPyproject(projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading pyproject.toml: %w", err)
+	}
+	if lockDir == "" {
+		if lockDir, err = findLock(projectPath); err != nil {
+			return nil, err
+		}
+	}
+	lock, err := scanner.ReadLock(lockDir)
+	if err != nil {
+		return nil, fmt.Errorf("reading uv.lock: %w", err)
+	}
+
+	serial, err := uuid()
+	if err != nil {
+		return nil, err
+	}
+	bom := &BOM{Created: time.Now().UTC().Truncate(time.Second), Serial: serial}
+
+	name := cmp.Or(pyproject.Project.Name, filepath.Base(projectPath))
+	root, hasRoot := lock.Package(name)
+	packages := lock.Packages
+	if lockDir, _ = filepath.Abs(lockDir); hasRoot && lockDir != projectPath {
+		packages = reachable(lock, root)
+	}
+
+	bom.Project = Component{Name: name, Version: pyproject.Project.Version, Local: true}
+	bom.Project.License = declaredLicense(pyproject.Project.License)
+	if hasRoot {
+		bom.Project.Version = cmp.Or(bom.Project.Version, root.Version)
+		bom.Project.DependsOn = dependsOn(lock, *root)
+	}
+	bom.Project.Ref = ref(bom.Project)
+
+	for _, pkg := range packages {
+		if hasRoot && scanner.NormalizeName(pkg.Name) == scanner.NormalizeName(root.Name) {
+			continue
+		}
+		component := component(pkg)
+		component.DependsOn = dependsOn(lock, pkg)
+		bom.Components = append(bom.Components, component)
+		// Without the project in the lock, e.g. a virtual workspace root, it depends on the local packages
+		if !hasRoot && component.Local {
+			bom.Project.DependsOn = append(bom.Project.DependsOn, component.Ref)
+		}
+	}
+	return bom, nil
+}
+
+// findLock returns the closest directory at or above dir that holds a uv.lock
+func findLock(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for d := dir; ; d = filepath.Dir(d) {
+		if _, err := os.Stat(filepath.Join(d, "uv.lock")); err == nil {
+			return d, nil
+		}
+		if filepath.Dir(d) == d {
+			return "", fmt.Errorf("no uv.lock in %s or its parents, run uv lock first", dir)
+		}
+	}
+}
+
+// reachable returns the packages the root depends on through any dependency, extra or group
+func reachable(lock *scanner.Lock, root *scanner.LockPackage) []scanner.LockPackage {
+	var found []scanner.LockPackage
+	seen := map[string]bool{ref(component(*root)): true}
+	var visit func(pkg scanner.LockPackage)
+	visit = func(pkg scanner.LockPackage) {
+		for _, dep := range edges(pkg) {
+			next, ok := lock.Dependency(dep)
+			if !ok || seen[ref(component(*next))] {
+				continue
+			}
+			seen[ref(component(*next))] = true
+			found = append(found, *next)
+			visit(*next)
+		}
+	}
+	visit(*root)
+	return found
+}
+
+// edges returns all of a package's dependency edges, including those of its extras and groups
+func edges(pkg scanner.LockPackage) []scanner.LockDependency {
+	deps := slices.Clone(pkg.Dependencies)
+	for _, extra := range slices.Sorted(maps.Keys(pkg.OptionalDependencies)) {
+		deps = append(deps, pkg.OptionalDependencies[extra]...)
+	}
+	for _, group := range slices.Sorted(maps.Keys(pkg.DevDependencies)) {
+		deps = append(deps, pkg.DevDependencies[group]...)
+	}
+	return deps
+}
+
+// dependsOn returns the refs of the packages a package depends on directly
+func dependsOn(lock *scanner.Lock, pkg scanner.LockPackage) []string {
+	var refs []string
+	for _, dep := range edges(pkg) {
+		if next, ok := lock.Dependency(dep); ok {
+			if r := ref(component(*next)); !slices.Contains(refs, r) {
+				refs = append(refs, r)
+			}
+		}
+	}
+	return refs
+}
+
+// component describes a locked package
+func component(pkg scanner.LockPackage) Component {
+	c := Component{Name: pkg.Name, Version: pkg.Version, Local: pkg.IsLocal()}
+	if !c.Local {
+		c.PURL = purl(pkg)
+	}
+	c.Ref = ref(c)
+
+	var artifacts []scanner.LockArtifact
+	if pkg.Sdist != nil {
+		artifacts = append(artifacts, *pkg.Sdist)
+	}
+	artifacts = append(artifacts, pkg.Wheels...)
+	for _, artifact := range artifacts {
+		if artifact.URL != "" {
+			c.Distributions = append(c.Distributions, Distribution{URL: artifact.URL, Hash: parseHash(artifact.Hash)})
+		}
+	}
+
+	switch {
+	case len(c.Distributions) > 0:
+		c.Download = c.Distributions[0].URL
+		c.Hash = c.Distributions[0].Hash
+	case pkg.Source.Git != "":
+		c.Download = "git+" + pkg.Source.Git
+	case pkg.Source.URL != "":
+		c.Download = pkg.Source.URL
+		if len(artifacts) > 0 {
+			c.Hash = parseHash(artifacts[0].Hash)
+		}
+	}
+	return c
+}
+
+// parseHash splits a uv.lock "algorithm:digest" hash, returning nil if there isn't one
+func parseHash(hash string) *Hash {
+	algorithm, value, ok := strings.Cut(hash, ":")
+	if !ok || value == "" {
+		return nil
+	}
+	return &Hash{Algorithm: algorithm, Value: value}
+}
+
+// purl returns the package URL of a package from an index, git or a direct URL
+func purl(pkg scanner.LockPackage) string {
+	p := "pkg:pypi/" + scanner.NormalizeName(pkg.Name)
+	if pkg.Version != "" {
+		p += "@" + url.PathEscape(pkg.Version)
+	}
+	switch {
+	case pkg.Source.Git != "":
+		p += "?vcs_url=" + url.QueryEscape("git+"+pkg.Source.Git)
+	case pkg.Source.URL != "":
+		p += "?download_url=" + url.QueryEscape(pkg.Source.URL)
+	}
+	return p
+}
+
+// ref returns the component's package URL, or its name and version if it has none
+func ref(c Component) string {
+	if c.PURL != "" {
+		return c.PURL
+	}
+	return scanner.NormalizeName(c.Name) + "@" + cmp.Or(c.Version, "0")
+}
+
+// declaredLicense classifies the license field of pyproject.toml, returning "" if it can't be
+func declaredLicense(field any) string {
+	var declared string
+	switch license := field.(type) {
+	case string:
+		declared = license
+	case map[string]any:
+		declared, _ = license["text"].(string)
+	}
+	id, _ := licenses.Classify(declared)
+	return id
+}
+
+// uuid returns a random version 4 UUID
+func uuid() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// Write encodes the BOM in the format
+func (b *BOM) Write(w io.Writer, format Format) error {
+	switch format {
+	case CycloneDX:
+		return b.writeCycloneDX(w)
+	case SPDX:
+		return b.writeSPDX(w)
+	default:
+		return errors.New("unknown SBOM format " + string(format))
+	}
+}
+
+// WriteFile builds the project's BOM and writes it into the project, returning the file's path
+func WriteFile(projectPath, lockDir string, format Format) (string, error) {
+	bom, err := Build(projectPath, lockDir)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(projectPath, format.Filename())
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	if err := bom.Write(f, format); err != nil {
+		f.Close()
+		return "", err
+	}
+	return path, f.Close()
+}
@@ -0,0 +1,149 @@
+package sbom
+
+import (
+	"encoding/json"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// spdxDocument is an SPDX 2.3 JSON document
+type spdxDocument struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	Name                  string            `json:"name"`
+	SPDXID                string            `json:"SPDXID"`
+	VersionInfo           string            `json:"versionInfo,omitempty"`
+	DownloadLocation      string            `json:"downloadLocation"`
+	FilesAnalyzed         bool              `json:"filesAnalyzed"`
+	LicenseConcluded      string            `json:"licenseConcluded"`
+	LicenseDeclared       string            `json:"licenseDeclared"`
+	CopyrightText         string            `json:"copyrightText"`
+	PrimaryPackagePurpose string            `json:"primaryPackagePurpose"`
+	Checksums             []spdxChecksum    `json:"checksums,omitempty"`
+	ExternalRefs          []spdxExternalRef `json:"externalRefs,omitempty"`
+}
+
+type spdxChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+// spdxAlgorithms maps uv.lock hash names to SPDX's
+var spdxAlgorithms = map[string]string{
+	"md5":    "MD5",
+	"sha1":   "SHA1",
+	"sha256": "SHA256",
+	"sha384": "SHA384",
+	"sha512": "SHA512",
+}
+
+// spdxUnsafe matches the characters an SPDX identifier can't contain
+var spdxUnsafe = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+
+// noAssertion is SPDX's value for something the document makes no claim about
+const noAssertion = "NOASSERTION"
+
+// spdxPackageOf converts a component, leaving out a hash SPDX has no name for
+func spdxPackageOf(c Component, id, purpose string) spdxPackage {
+	out := spdxPackage{
+		Name:                  c.Name,
+		SPDXID:                id,
+		VersionInfo:           c.Version,
+		DownloadLocation:      noAssertion,
+		LicenseConcluded:      noAssertion,
+		LicenseDeclared:       noAssertion,
+		CopyrightText:         noAssertion,
+		PrimaryPackagePurpose: purpose,
+	}
+	if c.Download != "" {
+		out.DownloadLocation = c.Download
+	}
+	// A LicenseRef would need its text in hasExtractedLicensingInfos, which tuv doesn't have for
+	// the loose names it classifies that way
+	if c.License != "" && !strings.Contains(c.License, "LicenseRef-") {
+		out.LicenseDeclared = c.License
+	}
+	// A package's checksums must all be of the file at its download location
+	if c.Hash != nil {
+		if alg, ok := spdxAlgorithms[strings.ToLower(c.Hash.Algorithm)]; ok {
+			out.Checksums = []spdxChecksum{{Algorithm: alg, ChecksumValue: c.Hash.Value}}
+		}
+	}
+	if c.PURL != "" {
+		out.ExternalRefs = []spdxExternalRef{{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: c.PURL}}
+	}
+	return out
+}
+
+// writeSPDX encodes the BOM as SPDX JSON
+func (b *BOM) writeSPDX(w io.Writer) error {
+	// SPDX identifiers are limited to letters, digits, dots and dashes, so refs are rewritten
+	// and numbered if two end up the same
+	ids := make(map[string]string)
+	used := make(map[string]bool)
+	id := func(ref string) string {
+		if id, ok := ids[ref]; ok {
+			return id
+		}
+		base := "SPDXRef-Package-" + strings.Trim(spdxUnsafe.ReplaceAllString(strings.TrimPrefix(ref, "pkg:pypi/"), "-"), "-")
+		id := base
+		for n := 2; used[id]; n++ {
+			id = base + "-" + strconv.Itoa(n)
+		}
+		ids[ref], used[id] = id, true
+		return id
+	}
+
+	projectID := id(b.Project.Ref)
+	doc := spdxDocument{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              b.Project.Name,
+		DocumentNamespace: "https://spdx.org/spdxdocs/" + spdxUnsafe.ReplaceAllString(b.Project.Name, "-") + "-" + b.Serial,
+		CreationInfo:      spdxCreationInfo{Created: b.Created.Format(time.RFC3339), Creators: []string{"Tool: tuv"}},
+		Packages:          []spdxPackage{spdxPackageOf(b.Project, projectID, "APPLICATION")},
+		Relationships:     []spdxRelationship{{SPDXElementID: "SPDXRef-DOCUMENT", RelationshipType: "DESCRIBES", RelatedSPDXElement: projectID}},
+	}
+	for _, c := range b.Components {
+		doc.Packages = append(doc.Packages, spdxPackageOf(c, id(c.Ref), "LIBRARY"))
+	}
+	for _, c := range append([]Component{b.Project}, b.Components...) {
+		for _, dep := range c.DependsOn {
+			doc.Relationships = append(doc.Relationships, spdxRelationship{SPDXElementID: id(c.Ref), RelationshipType: "DEPENDS_ON", RelatedSPDXElement: id(dep)})
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
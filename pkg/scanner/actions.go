@@ -0,0 +1,88 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SyncProject runs uv sync in the project directory
+func SyncProject(ctx context.Context, projectPath string) (string, error) {
+	return RunUVCommandContext(ctx, projectPath, "sync")
+}
+
+// SyncWorkspace runs uv sync for every member of a workspace from its root
+func SyncWorkspace(ctx context.Context, root string) (string, error) {
+	return RunUVCommandContext(ctx, root, "sync", "--all-packages")
+}
+
+// LockProject runs uv lock in the project directory
+func LockProject(ctx context.Context, projectPath string) (string, error) {
+	return RunUVCommandContext(ctx, projectPath, "lock")
+}
+
+// CheckLock runs uv lock --check and reports whether uv.lock still matches pyproject.toml
+func CheckLock(ctx context.Context, projectPath string) (bool, string, error) {
+	output, err := RunUVCommandContext(ctx, projectPath, "lock", "--check")
+	if err != nil && strings.Contains(output, "needs to be updated") {
+		return false, output, nil
+	}
+	return err == nil, output, err
+}
+
+// ResolveDrift brings uv.lock and .venv back in line with pyproject.toml. The lock is only
+// rewritten if uv lock --check says it is out of date; the venv is always synced.
+func ResolveDrift(ctx context.Context, projectPath string) (string, error) {
+	current, output, err := CheckLock(ctx, projectPath)
+	if err != nil {
+		return output, err
+	}
+	if !current {
+		if lockOutput, err := LockProject(ctx, projectPath); err != nil {
+			return lockOutput, err
+		}
+	}
+	return SyncProject(ctx, projectPath)
+}
+
+// MigrateToUV moves a project that tuv doesn't convert itself onto uv. Hatch projects already use
+// PEP 621 metadata and only need a lock. PDM projects are handed to the third-party migrate-to-uv
+// tool, which uv downloads from PyPI and runs; it rewrites pyproject.toml and locks it.
+func MigrateToUV(ctx context.Context, projectPath string, tool Tool) (string, error) {
+	switch tool {
+	case ToolUV:
+		return "", fmt.Errorf("%s is already a uv project", projectPath)
+	case ToolHatch:
+		return LockProject(ctx, projectPath)
+	case ToolPDM:
+		return RunUVCommandContext(ctx, projectPath, "tool", "run", "migrate-to-uv")
+	default:
+		return "", fmt.Errorf("%s projects are converted by tuv, not migrated here", tool.Label())
+	}
+}
+
+// DeleteVenv removes the project's .venv directory
+func DeleteVenv(projectPath string) error {
+	venvPath := filepath.Join(projectPath, ".venv")
+	if _, err := os.Stat(venvPath); os.IsNotExist(err) {
+		return fmt.Errorf("no virtual environment in %s", projectPath)
+	}
+	return os.RemoveAll(venvPath)
+}
+
+// DeleteProject removes a project directory, refusing anything outside the parent directory
+func (s *Scanner) DeleteProject(projectPath string) error {
+	if !inside(s.ParentDir, projectPath) {
+		return fmt.Errorf("refusing to delete %s: not inside %s", projectPath, s.ParentDir)
+	}
+	return os.RemoveAll(projectPath)
+}
+
+// inside reports whether path is below dir, and not dir itself. Names such as ..cache are
+// children, not the parent directory.
+func inside(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,55 @@
+package scanner
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LastActivity returns the most recent time a project was worked on. It takes the newest of
+// the last git commit and the newest file modification, ignoring the venv, caches and VCS data
+// whose timestamps change without the project itself being touched.
+func LastActivity(projectPath string) time.Time {
+	var latest time.Time
+
+	if _, err := os.Stat(filepath.Join(projectPath, ".git")); err == nil {
+		if commitTime, err := lastCommitTime(projectPath); err == nil {
+			latest = commitTime
+		}
+	}
+
+	filepath.WalkDir(projectPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			switch d.Name() {
+			case ".venv", ".git", "node_modules", "__pycache__", ".pytest_cache", ".mypy_cache", ".ruff_cache", "build", "dist":
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info, err := d.Info(); err == nil && info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+		return nil
+	})
+
+	return latest
+}
+
+// lastCommitTime returns the time of the last commit on HEAD
+func lastCommitTime(projectPath string) (time.Time, error) {
+	output, err := runGit(projectPath, "log", "-1", "--format=%ct")
+	if err != nil {
+		return time.Time{}, err
+	}
+	seconds, err := strconv.ParseInt(strings.TrimSpace(output), 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(seconds, 0), nil
+}
@@ -0,0 +1,170 @@
+package scanner
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// CleanCategory identifies a kind of reclaimable data inside a project
+type CleanCategory string
+
+const (
+	CleanVenv     CleanCategory = "venv"
+	CleanBytecode CleanCategory = "pycache"
+	CleanPytest   CleanCategory = "pytest"
+	CleanMypy     CleanCategory = "mypy"
+	CleanRuff     CleanCategory = "ruff"
+	CleanBuild    CleanCategory = "build"
+)
+
+// CleanCategories lists every category in display order
+var CleanCategories = []CleanCategory{
+	CleanVenv,
+	CleanBytecode,
+	CleanPytest,
+	CleanMypy,
+	CleanRuff,
+	CleanBuild,
+}
+
+// Label returns a human-readable name for the category
+func (c CleanCategory) Label() string {
+	switch c {
+	case CleanVenv:
+		return "Virtual environment (.venv)"
+	case CleanBytecode:
+		return "Bytecode (__pycache__)"
+	case CleanPytest:
+		return "pytest cache (.pytest_cache)"
+	case CleanMypy:
+		return "mypy cache (.mypy_cache)"
+	case CleanRuff:
+		return "ruff cache (.ruff_cache)"
+	case CleanBuild:
+		return "Build artifacts (build, dist, *.egg-info)"
+	default:
+		return string(c)
+	}
+}
+
+// CleanTarget is the set of paths in one project belonging to a category
+type CleanTarget struct {
+	Category CleanCategory
+	Paths    []string
+	Size     int64
+}
+
+// FindReclaimable returns the reclaimable paths in a project grouped by category
+func FindReclaimable(projectPath string) ([]CleanTarget, error) {
+	found := make(map[CleanCategory]*CleanTarget)
+	add := func(category CleanCategory, path string, size int64) {
+		target, ok := found[category]
+		if !ok {
+			target = &CleanTarget{Category: category}
+			found[category] = target
+		}
+		target.Paths = append(target.Paths, path)
+		target.Size += size
+	}
+
+	// Top-level build artifacts
+	for _, name := range []string{"build", "dist"} {
+		path := filepath.Join(projectPath, name)
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			size, _ := getDirSize(path)
+			add(CleanBuild, path, size)
+		}
+	}
+	eggInfos, _ := filepath.Glob(filepath.Join(projectPath, "*.egg-info"))
+	for _, path := range eggInfos {
+		size, _ := getDirSize(path)
+		add(CleanBuild, path, size)
+	}
+
+	venvPath := filepath.Join(projectPath, ".venv")
+	if info, err := os.Stat(venvPath); err == nil && info.IsDir() {
+		size, _ := getDirSize(venvPath)
+		add(CleanVenv, venvPath, size)
+	}
+
+	// Caches can be nested anywhere outside the venv and version control
+	err := filepath.WalkDir(projectPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if !d.IsDir() || path == projectPath {
+			return nil
+		}
+
+		var category CleanCategory
+		switch d.Name() {
+		case ".venv", ".git", "node_modules":
+			return filepath.SkipDir
+		case "__pycache__":
+			category = CleanBytecode
+		case ".pytest_cache":
+			category = CleanPytest
+		case ".mypy_cache":
+			category = CleanMypy
+		case ".ruff_cache":
+			category = CleanRuff
+		default:
+			return nil
+		}
+
+		size, _ := walkSize(path)
+		add(category, path, size)
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []CleanTarget
+	for _, category := range CleanCategories {
+		if target, ok := found[category]; ok {
+			targets = append(targets, *target)
+		}
+	}
+	return targets, nil
+}
+
+// Clean removes the paths of the given targets and returns the number of bytes freed
+func Clean(projectPath string, targets []CleanTarget) (int64, error) {
+	var freed int64
+	for _, target := range targets {
+		removed := false
+		for _, path := range target.Paths {
+			// Never remove anything outside the project directory
+			if !inside(projectPath, path) {
+				continue
+			}
+			if err := os.RemoveAll(path); err != nil {
+				return freed, err
+			}
+			removed = true
+		}
+		if removed {
+			freed += target.Size
+		}
+	}
+	return freed, nil
+}
+
+// walkSize sums file sizes below path without shelling out, for small directories
+func walkSize(path string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			if info, err := d.Info(); err == nil {
+				size += info.Size()
+			}
+		}
+		return nil
+	})
+	return size, err
+}
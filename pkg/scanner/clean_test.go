@@ -0,0 +1,58 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestClean(t *testing.T) {
+	dir := t.TempDir()
+	project := filepath.Join(dir, "proj")
+	outside := filepath.Join(dir, "outside")
+	for _, path := range []string{filepath.Join(project, "..cache"), filepath.Join(project, "__pycache__"), outside} {
+		if err := os.MkdirAll(path, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		paths   []string
+		freed   int64
+		removed []string
+		kept    []string
+	}{
+		{name: "child starting with ..", paths: []string{filepath.Join(project, "..cache")}, freed: 10, removed: []string{filepath.Join(project, "..cache")}},
+		{name: "outside the project", paths: []string{outside}, freed: 0, kept: []string{outside}},
+		{name: "the project itself", paths: []string{project}, freed: 0, kept: []string{project}},
+		{
+			name:    "mixed",
+			paths:   []string{filepath.Join(project, "..", "outside"), filepath.Join(project, "__pycache__")},
+			freed:   10,
+			removed: []string{filepath.Join(project, "__pycache__")},
+			kept:    []string{outside},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			freed, err := Clean(project, []CleanTarget{{Category: CleanBytecode, Paths: tt.paths, Size: 10}})
+			if err != nil {
+				t.Fatalf("Clean: %v", err)
+			}
+			if freed != tt.freed {
+				t.Errorf("Clean freed %d, want %d", freed, tt.freed)
+			}
+			for _, path := range tt.removed {
+				if _, err := os.Stat(path); !os.IsNotExist(err) {
+					t.Errorf("%s was not removed", path)
+				}
+			}
+			for _, path := range tt.kept {
+				if _, err := os.Stat(path); err != nil {
+					t.Errorf("%s was removed", path)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// Tool is the packaging tool a project is managed with
+type Tool string
+
+const (
+	ToolUV           Tool = "uv"
+	ToolPoetry       Tool = "poetry"
+	ToolPDM          Tool = "pdm"
+	ToolHatch        Tool = "hatch"
+	ToolRequirements Tool = "requirements"
+)
+
+// Label returns the tool's name as shown in the UI
+func (t Tool) Label() string {
+	switch t {
+	case ToolUV:
+		return "uv"
+	case ToolPoetry:
+		return "Poetry"
+	case ToolPDM:
+		return "PDM"
+	case ToolHatch:
+		return "Hatch"
+	case ToolRequirements:
+		return "requirements.txt"
+	default:
+		return string(t)
+	}
+}
+
+// Probe is what detectors look at: a directory and its pyproject.toml, if it has one
+type Probe struct {
+	Dir       string
+	pyproject map[string]any
+}
+
+// NewProbe reads a directory's pyproject.toml for detectors. A missing or broken file is treated as empty.
+func NewProbe(dir string) Probe {
+	probe := Probe{Dir: dir}
+	if data, err := os.ReadFile(filepath.Join(dir, "pyproject.toml")); err == nil {
+		_ = toml.Unmarshal(data, &probe.pyproject)
+	}
+	return probe
+}
+
+// Has reports whether any of the glob patterns match a file in the directory
+func (p Probe) Has(patterns ...string) bool {
+	for _, pattern := range patterns {
+		if matches, _ := filepath.Glob(filepath.Join(p.Dir, pattern)); len(matches) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// HasTable reports whether pyproject.toml has a table at the given path, e.g. "tool", "poetry"
+func (p Probe) HasTable(path ...string) bool {
+	table := p.pyproject
+	for _, name := range path {
+		next, ok := table[name].(map[string]any)
+		if !ok {
+			return false
+		}
+		table = next
+	}
+	return table != nil
+}
+
+// BuildBackend returns the build-backend named in pyproject.toml's [build-system], or ""
+func (p Probe) BuildBackend() string {
+	buildSystem, _ := p.pyproject["build-system"].(map[string]any)
+	backend, _ := buildSystem["build-backend"].(string)
+	return strings.TrimSpace(backend)
+}
+
+// Detector recognises projects managed by one tool
+type Detector interface {
+	Tool() Tool
+	Detect(p Probe) bool
+}
+
+// detector is a Detector backed by a function
+type detector struct {
+	tool   Tool
+	detect func(p Probe) bool
+}
+
+func (d detector) Tool() Tool          { return d.tool }
+func (d detector) Detect(p Probe) bool { return d.detect(p) }
+
+// Detectors are tried in order and the first match classifies a directory. Lock files and tool
+// tables come first, so a plain PEP 621 project only falls through to uv when nothing else claims it.
+var Detectors = []Detector{
+	detector{ToolUV, func(p Probe) bool {
+		return p.Has("uv.lock") || p.HasTable("tool", "uv")
+	}},
+	detector{ToolPoetry, func(p Probe) bool {
+		return p.Has("poetry.lock") || p.HasTable("tool", "poetry")
+	}},
+	detector{ToolPDM, func(p Probe) bool {
+		return p.Has("pdm.lock") || p.HasTable("tool", "pdm")
+	}},
+	detector{ToolHatch, func(p Probe) bool {
+		return p.Has("hatch.toml") || p.HasTable("tool", "hatch") || strings.HasPrefix(p.BuildBackend(), "hatchling")
+	}},
+	detector{ToolRequirements, func(p Probe) bool {
+		return !p.HasTable("project") && p.Has("requirements*.txt", "requirements/*.txt")
+	}},
+	detector{ToolUV, func(p Probe) bool {
+		return p.Has("pyproject.toml", ".python-version")
+	}},
+}
+
+// Detect classifies a directory's tooling, reporting false if it isn't a Python project
+func Detect(dir string) (Tool, bool) {
+	probe := NewProbe(dir)
+	for _, d := range Detectors {
+		if d.Detect(probe) {
+			return d.Tool(), true
+		}
+	}
+	return "", false
+}
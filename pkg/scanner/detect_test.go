@@ -0,0 +1,54 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  Tool
+		ok    bool
+	}{
+		{name: "uv lock", files: map[string]string{"pyproject.toml": "[project]\nname = \"a\"\n", "uv.lock": ""}, want: ToolUV, ok: true},
+		{name: "plain PEP 621", files: map[string]string{"pyproject.toml": "[project]\nname = \"a\"\n"}, want: ToolUV, ok: true},
+		{name: "poetry table", files: map[string]string{"pyproject.toml": "[tool.poetry]\nname = \"a\"\n"}, want: ToolPoetry, ok: true},
+		{name: "pdm lock", files: map[string]string{"pyproject.toml": "", "pdm.lock": ""}, want: ToolPDM, ok: true},
+		{name: "hatch.toml", files: map[string]string{"pyproject.toml": "", "hatch.toml": ""}, want: ToolHatch, ok: true},
+		{name: "hatch envs", files: map[string]string{"pyproject.toml": "[tool.hatch.envs.default]\n"}, want: ToolHatch, ok: true},
+		{name: "hatch version", files: map[string]string{"pyproject.toml": "[tool.hatch.version]\npath = \"a/__init__.py\"\n"}, want: ToolHatch, ok: true},
+		{name: "hatch build", files: map[string]string{"pyproject.toml": "[tool.hatch.build.targets.wheel]\npackages = [\"a\"]\n"}, want: ToolHatch, ok: true},
+		{name: "hatch metadata", files: map[string]string{"pyproject.toml": "[tool.hatch.metadata]\nallow-direct-references = true\n"}, want: ToolHatch, ok: true},
+		{
+			name:  "hatchling backend",
+			files: map[string]string{"pyproject.toml": "[build-system]\nrequires = [\"hatchling\"]\nbuild-backend = \"hatchling.build\"\n"},
+			want:  ToolHatch,
+			ok:    true,
+		},
+		{
+			name:  "hatchling backend with uv table",
+			files: map[string]string{"pyproject.toml": "[build-system]\nbuild-backend = \"hatchling.build\"\n\n[tool.uv]\npackage = true\n"},
+			want:  ToolUV,
+			ok:    true,
+		},
+		{name: "requirements only", files: map[string]string{"requirements.txt": "requests\n"}, want: ToolRequirements, ok: true},
+		{name: "not python", files: map[string]string{"README.md": ""}, ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, body := range tt.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, ok := Detect(dir)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("Detect = %q, %v, want %q, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,315 @@
+package scanner
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Drift lists how a project has moved away from its uv.lock
+type Drift struct {
+	// Lock lists dependencies in pyproject.toml that uv.lock no longer matches
+	Lock []string
+	// Venv lists packages in .venv that are missing, extra or at a different version than uv.lock
+	Venv []string
+}
+
+// Summary describes the drift in one line for the project list and details
+func (d *Drift) Summary() string {
+	var parts []string
+	if n := len(d.Lock); n > 0 {
+		parts = append(parts, plural(n, "dependency", "dependencies")+" changed since uv.lock")
+	}
+	if n := len(d.Venv); n > 0 {
+		parts = append(parts, plural(n, "package", "packages")+" in .venv differ from uv.lock")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// plural formats a count with the singular or plural noun
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, one)
+	}
+	return fmt.Sprintf("%d %s", n, many)
+}
+
+// DetectDrift compares a project's pyproject.toml with the requirements uv.lock in lockDir recorded
+// for it, and, if checkVenv is set, the packages installed in lockDir's .venv with the locked versions.
+// Markers are not evaluated, so only requirements' names, extras and specifiers are compared.
+// It returns nil if nothing drifted or the files can't be read.
+func DetectDrift(projectPath, lockDir string, checkVenv bool) *Drift {
+	lock, err := ReadLock(lockDir)
+	if err != nil {
+		return nil
+	}
+	pyproject, err := ReadPyproject(projectPath)
+	if err != nil {
+		return nil
+	}
+
+	// A virtual workspace root has no [project] and so no package in the lock to compare with,
+	// but its .venv can still drift
+	drift := &Drift{}
+	var root *LockPackage
+	if name := pyproject.Project.Name; name != "" {
+		var ok bool
+		if root, ok = lock.Package(name); !ok {
+			drift.Lock = append(drift.Lock, name+" itself is not in uv.lock")
+		} else {
+			drift.Lock = lockDrift(pyproject, root)
+		}
+	}
+	if checkVenv {
+		drift.Venv = venvDrift(lock, root, lockDir)
+	}
+
+	if len(drift.Lock) == 0 && len(drift.Venv) == 0 {
+		return nil
+	}
+	return drift
+}
+
+// lockDrift compares the project's dependencies and groups with what the lock recorded for them
+func lockDrift(pyproject *Pyproject, root *LockPackage) []string {
+	declared := slices.Clone(pyproject.Project.Dependencies)
+	for _, extra := range pyproject.Project.OptionalDependencies {
+		declared = append(declared, extra...)
+	}
+	recorded := requirementKeys(root.Metadata.RequiresDist)
+	changes := compareRequirements("", requirementStrings(declared), recorded)
+
+	groups := pyproject.Groups()
+	var names []string
+	for name := range groups {
+		names = append(names, name)
+	}
+	for name := range root.Metadata.RequiresDev {
+		if _, ok := groups[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		changes = append(changes, compareRequirements(name, requirementStrings(groups[name]), requirementKeys(root.Metadata.RequiresDev[name]))...)
+	}
+	return changes
+}
+
+// compareRequirements lists requirements that were added, removed or changed since the lock was
+// written. group names the dependency group, or is empty for the project's own dependencies.
+func compareRequirements(group string, declared, recorded []string) []string {
+	where := ""
+	if group != "" {
+		where = " (group " + group + ")"
+	}
+
+	var added []string
+	recorded = slices.Clone(recorded)
+	for _, req := range declared {
+		if i := slices.Index(recorded, req); i >= 0 {
+			recorded = slices.Delete(recorded, i, i+1)
+		} else {
+			added = append(added, req)
+		}
+	}
+
+	var changes []string
+	for _, req := range added {
+		i := slices.IndexFunc(recorded, func(old string) bool { return keyName(old) == keyName(req) })
+		if i < 0 {
+			changes = append(changes, req+where+" is not in uv.lock")
+			continue
+		}
+		previous := strings.TrimPrefix(recorded[i], keyName(req))
+		if previous == "" {
+			previous = "any version"
+		}
+		changes = append(changes, fmt.Sprintf("%s%s changed from %s", req, where, previous))
+		recorded = slices.Delete(recorded, i, i+1)
+	}
+	for _, req := range recorded {
+		changes = append(changes, req+where+" is in uv.lock but not in pyproject.toml")
+	}
+	return changes
+}
+
+// keyName returns the package name at the start of a requirement key
+func keyName(key string) string {
+	if i := strings.IndexAny(key, "[<>=!~"); i >= 0 {
+		return key[:i]
+	}
+	return key
+}
+
+// requirementStrings normalises PEP 508 requirement strings for comparison
+func requirementStrings(reqs []string) []string {
+	var keys []string
+	for _, req := range reqs {
+		name, extras, specifier := splitRequirement(req)
+		keys = append(keys, requirementKey(name, extras, specifier))
+	}
+	return keys
+}
+
+// requirementKeys normalises requirements recorded in uv.lock for comparison
+func requirementKeys(reqs []LockRequirement) []string {
+	var keys []string
+	for _, req := range reqs {
+		keys = append(keys, requirementKey(req.Name, req.Extras, req.Specifier))
+	}
+	return keys
+}
+
+// requirementKey formats a requirement as name[extras]specifier with the name normalised and
+// the extras and specifier clauses sorted, so equivalent requirements compare equal
+func requirementKey(name string, extras []string, specifier string) string {
+	key := NormalizeName(name)
+	if len(extras) > 0 {
+		extras = slices.Clone(extras)
+		for i := range extras {
+			extras[i] = NormalizeName(extras[i])
+		}
+		slices.Sort(extras)
+		key += "[" + strings.Join(extras, ",") + "]"
+	}
+	clauses := strings.Split(strings.ReplaceAll(specifier, " ", ""), ",")
+	clauses = slices.DeleteFunc(clauses, func(c string) bool { return c == "" })
+	slices.Sort(clauses)
+	return key + strings.Join(clauses, ",")
+}
+
+// splitRequirement breaks a PEP 508 requirement into its name, extras and version specifier,
+// dropping any marker or direct URL
+func splitRequirement(req string) (name string, extras []string, specifier string) {
+	req, _, _ = strings.Cut(req, ";")
+	req, _, _ = strings.Cut(req, " @ ")
+	req = strings.TrimSpace(req)
+
+	end := strings.IndexAny(req, "[<>=!~ (")
+	if end < 0 {
+		return req, nil, ""
+	}
+	name, rest := req[:end], strings.TrimSpace(req[end:])
+	if strings.HasPrefix(rest, "[") {
+		inside, after, _ := strings.Cut(rest[1:], "]")
+		for _, extra := range strings.Split(inside, ",") {
+			if extra = strings.TrimSpace(extra); extra != "" {
+				extras = append(extras, extra)
+			}
+		}
+		rest = strings.TrimSpace(after)
+	}
+	rest = strings.TrimSuffix(strings.TrimPrefix(rest, "("), ")")
+	return name, extras, rest
+}
+
+// venvDrift compares the packages installed in dir's .venv with uv.lock. root, if known, is the
+// project whose dependencies should be installed; packages it needs that are missing are reported too.
+func venvDrift(lock *Lock, root *LockPackage, dir string) []string {
+	installed := InstalledPackages(dir)
+	if installed == nil {
+		return nil
+	}
+
+	var changes []string
+	names := make([]string, 0, len(installed))
+	for name := range installed {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		version := installed[name]
+		var locked []string
+		for _, pkg := range lock.Packages {
+			if NormalizeName(pkg.Name) == name {
+				locked = append(locked, pkg.Version)
+			}
+		}
+		switch {
+		case len(locked) == 0:
+			changes = append(changes, fmt.Sprintf("%s %s is installed but not in uv.lock", name, version))
+		case !slices.Contains(locked, version):
+			changes = append(changes, fmt.Sprintf("%s %s is installed, uv.lock has %s", name, version, strings.Join(locked, ", ")))
+		}
+	}
+
+	if root != nil {
+		for _, pkg := range lock.required(root) {
+			if _, ok := installed[NormalizeName(pkg.Name)]; !ok && pkg.Source.Virtual == "" {
+				changes = append(changes, fmt.Sprintf("%s %s is in uv.lock but not installed", pkg.Name, pkg.Version))
+			}
+		}
+	}
+	return changes
+}
+
+// required returns the packages uv sync installs for root by default: its dependencies and its dev
+// group, followed transitively. Dependencies behind a marker are skipped, since they may not apply here.
+func (l *Lock) required(root *LockPackage) []*LockPackage {
+	var found []*LockPackage
+	seen := make(map[string]bool)
+	var visit func(deps []LockDependency)
+	visit = func(deps []LockDependency) {
+		for _, dep := range deps {
+			if dep.Marker != "" {
+				continue
+			}
+			next, ok := l.Dependency(dep)
+			if !ok {
+				continue
+			}
+			key := NormalizeName(next.Name)
+			if !seen[key] {
+				seen[key] = true
+				found = append(found, next)
+				visit(next.Dependencies)
+			}
+			// Extras pull in the dependency's optional dependencies even if it was already seen
+			for _, extra := range dep.Extra {
+				if extraKey := key + "[" + extra + "]"; !seen[extraKey] {
+					seen[extraKey] = true
+					visit(next.OptionalDependencies[extra])
+				}
+			}
+		}
+	}
+	seen[NormalizeName(root.Name)] = true
+	visit(root.Dependencies)
+	visit(root.DevDependencies["dev"])
+	return found
+}
+
+// Dependency finds the package a dependency edge points to, matching its version if the lock has several
+func (l *Lock) Dependency(dep LockDependency) (*LockPackage, bool) {
+	if dep.Version != "" {
+		for i := range l.Packages {
+			pkg := &l.Packages[i]
+			if NormalizeName(pkg.Name) == NormalizeName(dep.Name) && pkg.Version == dep.Version {
+				return pkg, true
+			}
+		}
+	}
+	return l.Package(dep.Name)
+}
+
+// InstalledPackages returns the packages installed in dir's .venv by normalised name, with their
+// versions, or nil if there is no venv
+func InstalledPackages(dir string) map[string]string {
+	sitePackages := SitePackages(dir)
+	if sitePackages == "" {
+		return nil
+	}
+	distInfos, _ := filepath.Glob(filepath.Join(sitePackages, "*.dist-info"))
+	installed := make(map[string]string, len(distInfos))
+	for _, distInfo := range distInfos {
+		base := strings.TrimSuffix(filepath.Base(distInfo), ".dist-info")
+		name, version, ok := strings.Cut(base, "-")
+		if !ok {
+			continue
+		}
+		installed[NormalizeName(name)] = version
+	}
+	return installed
+}
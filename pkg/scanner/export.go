@@ -0,0 +1,177 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ExportFile is a kind of requirements file uv export can produce
+type ExportFile int
+
+const (
+	// RequirementsFile holds the project's dependencies and any chosen extras and groups
+	RequirementsFile ExportFile = iota
+	// DevRequirementsFile holds only the chosen dependency groups
+	DevRequirementsFile
+	// ConstraintsFile pins the same packages as RequirementsFile without the project itself,
+	// for use with pip install -c
+	ConstraintsFile
+)
+
+// ExportFiles lists the files in the order the export screen cycles through them
+var ExportFiles = []ExportFile{RequirementsFile, DevRequirementsFile, ConstraintsFile}
+
+// Filename returns the name the file is written to in the project
+func (f ExportFile) Filename() string {
+	switch f {
+	case DevRequirementsFile:
+		return "requirements-dev.txt"
+	case ConstraintsFile:
+		return "constraints.txt"
+	default:
+		return "requirements.txt"
+	}
+}
+
+// ExportOptions chooses what ExportRequirements includes
+type ExportOptions struct {
+	File   ExportFile
+	Hashes bool
+	// Extras and Groups are the optional dependencies and dependency groups to include.
+	// Ones the project doesn't define are skipped, so the same options can be used for several projects.
+	Extras []string
+	Groups []string
+}
+
+// exportArgs returns the uv export arguments for a project with the given pyproject.toml
+func exportArgs(pyproject *Pyproject, opts ExportOptions) ([]string, error) {
+	args := []string{"export", "--format", "requirements-txt", "--quiet"}
+	if !opts.Hashes {
+		args = append(args, "--no-hashes")
+	}
+
+	groups := pyproject.Groups()
+	var chosen []string
+	for _, group := range opts.Groups {
+		if _, ok := groups[group]; ok {
+			chosen = append(chosen, group)
+		}
+	}
+
+	if opts.File == DevRequirementsFile {
+		if len(chosen) == 0 {
+			return nil, errors.New("the project has none of the chosen dependency groups")
+		}
+		for _, group := range chosen {
+			args = append(args, "--only-group", group)
+		}
+		return args, nil
+	}
+
+	args = append(args, "--no-default-groups")
+	for _, extra := range opts.Extras {
+		if _, ok := pyproject.Project.OptionalDependencies[extra]; ok {
+			args = append(args, "--extra", extra)
+		}
+	}
+	for _, group := range chosen {
+		args = append(args, "--group", group)
+	}
+	if opts.File == ConstraintsFile {
+		args = append(args, "--no-emit-project", "--no-emit-workspace")
+	}
+	return args, nil
+}
+
+// ExportRequirements runs uv export for the project and returns the file's contents. If it fails,
+// the returned string is uv's error output instead.
+func ExportRequirements(ctx context.Context, projectPath string, opts ExportOptions) (string, error) {
+	pyproject, err := ReadPyproject(projectPath)
+	if err != nil {
+		return "", err
+	}
+	args, err := exportArgs(pyproject, opts)
+	if err != nil {
+		return "", err
+	}
+
+	cmd := exec.CommandContext(ctx, "uv", args...)
+	cmd.Dir = projectPath
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return string(exitErr.Stderr), fmt.Errorf("uv export failed: %w", err)
+		}
+		return "", err
+	}
+	if opts.File == ConstraintsFile {
+		return constraintsOnly(string(output)), nil
+	}
+	return string(output), nil
+}
+
+// constraintsOnly drops the requirements pip install -c rejects from uv export output: editable
+// installs and packages given as a path or URL rather than a name. Their hash and via lines go with
+// them, and a comment says what was left out.
+func constraintsOnly(requirements string) string {
+	var out []string
+	skipping := false
+	for _, line := range strings.Split(requirements, "\n") {
+		// Indented lines continue the requirement above them
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			if !skipping {
+				out = append(out, line)
+			}
+			continue
+		}
+		req := strings.TrimSpace(strings.TrimSuffix(line, "\\"))
+		skipping = req != "" && !strings.HasPrefix(req, "#") && !namedRequirement(req)
+		if skipping {
+			out = append(out, "# "+req+" is left out: pip doesn't accept paths, URLs or editable installs as constraints")
+			continue
+		}
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
+
+// namedRequirement reports whether a requirements.txt line is a package name with an optional
+// version, rather than an option such as -e, a path or a URL
+func namedRequirement(req string) bool {
+	req, _, _ = strings.Cut(req, ";")
+	first, _ := utf8.DecodeRuneInString(req)
+	if !unicode.IsLetter(first) && !unicode.IsDigit(first) {
+		return false
+	}
+	return !strings.Contains(req, "://") && !strings.Contains(req, " @ ")
+}
+
+// ExportChoices returns the extras and dependency groups defined across the projects, sorted
+func ExportChoices(projects []UVProject) (extras, groups []string) {
+	for _, project := range projects {
+		pyproject, err := ReadPyproject(project.Path)
+		if err != nil {
+			continue
+		}
+		for extra := range pyproject.Project.OptionalDependencies {
+			if !slices.Contains(extras, extra) {
+				extras = append(extras, extra)
+			}
+		}
+		for group := range pyproject.Groups() {
+			if !slices.Contains(groups, group) {
+				groups = append(groups, group)
+			}
+		}
+	}
+	slices.Sort(extras)
+	slices.Sort(groups)
+	return extras, groups
+}
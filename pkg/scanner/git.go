@@ -0,0 +1,85 @@
+package scanner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GitStatus summarises a project's git repository using local refs only
+type GitStatus struct {
+	Branch      string
+	Dirty       bool
+	HasUpstream bool
+	Upstream    string
+	Ahead       int
+	Behind      int
+	LastCommit  time.Time
+	LastAuthor  string
+}
+
+// ReadGitStatus returns the git status of a project, or nil if it is not a git repository
+func ReadGitStatus(projectPath string) *GitStatus {
+	if _, err := os.Stat(filepath.Join(projectPath, ".git")); err != nil {
+		return nil
+	}
+
+	output, err := runGit(projectPath, "status", "--porcelain=v2", "--branch")
+	if err != nil {
+		return nil
+	}
+
+	status := &GitStatus{}
+	for _, line := range strings.Split(output, "\n") {
+		switch {
+		case strings.HasPrefix(line, "# branch.head "):
+			status.Branch = strings.TrimPrefix(line, "# branch.head ")
+		case strings.HasPrefix(line, "# branch.upstream "):
+			status.HasUpstream = true
+			status.Upstream = strings.TrimPrefix(line, "# branch.upstream ")
+		case strings.HasPrefix(line, "# branch.ab "):
+			fields := strings.Fields(strings.TrimPrefix(line, "# branch.ab "))
+			if len(fields) == 2 {
+				status.Ahead, _ = strconv.Atoi(strings.TrimPrefix(fields[0], "+"))
+				status.Behind, _ = strconv.Atoi(strings.TrimPrefix(fields[1], "-"))
+			}
+		case line != "" && !strings.HasPrefix(line, "#"):
+			status.Dirty = true
+		}
+	}
+
+	// A repository without commits has no log
+	if output, err := runGit(projectPath, "log", "-1", "--format=%ct%x1f%an"); err == nil {
+		seconds, author, _ := strings.Cut(strings.TrimSpace(output), "\x1f")
+		if n, err := strconv.ParseInt(seconds, 10, 64); err == nil {
+			status.LastCommit = time.Unix(n, 0)
+		}
+		status.LastAuthor = author
+	}
+
+	return status
+}
+
+// Indicator returns a compact summary such as "main* ↑1 ↓2"
+func (g *GitStatus) Indicator() string {
+	if g == nil {
+		return ""
+	}
+	indicator := g.Branch
+	if g.Branch == "(detached)" {
+		indicator = "detached"
+	}
+	if g.Dirty {
+		indicator += "*"
+	}
+	if g.Ahead > 0 {
+		indicator += fmt.Sprintf(" ↑%d", g.Ahead)
+	}
+	if g.Behind > 0 {
+		indicator += fmt.Sprintf(" ↓%d", g.Behind)
+	}
+	return indicator
+}
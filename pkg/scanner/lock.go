@@ -0,0 +1,122 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// Lock holds the parts of uv.lock that tuv reads
+type Lock struct {
+	Version        int           `toml:"version"`
+	RequiresPython string        `toml:"requires-python"`
+	Packages       []LockPackage `toml:"package"`
+}
+
+// LockPackage is one resolved package in uv.lock
+type LockPackage struct {
+	Name                 string                      `toml:"name"`
+	Version              string                      `toml:"version"`
+	Source               LockSource                  `toml:"source"`
+	Dependencies         []LockDependency            `toml:"dependencies"`
+	OptionalDependencies map[string][]LockDependency `toml:"optional-dependencies"`
+	DevDependencies      map[string][]LockDependency `toml:"dev-dependencies"`
+	Sdist                *LockArtifact               `toml:"sdist"`
+	Wheels               []LockArtifact              `toml:"wheels"`
+	Metadata             struct {
+		RequiresDist []LockRequirement            `toml:"requires-dist"`
+		RequiresDev  map[string][]LockRequirement `toml:"requires-dev"`
+	} `toml:"metadata"`
+}
+
+// LockSource is where a locked package comes from. Exactly one field is set.
+type LockSource struct {
+	Registry  string `toml:"registry"`
+	Editable  string `toml:"editable"`
+	Virtual   string `toml:"virtual"`
+	Directory string `toml:"directory"`
+	Path      string `toml:"path"`
+	Git       string `toml:"git"`
+	URL       string `toml:"url"`
+}
+
+// LockDependency is an edge from a locked package to another
+type LockDependency struct {
+	Name    string   `toml:"name"`
+	Version string   `toml:"version"`
+	Extra   []string `toml:"extra"`
+	Marker  string   `toml:"marker"`
+}
+
+// LockArtifact is a downloadable sdist or wheel with its hash
+type LockArtifact struct {
+	URL  string `toml:"url"`
+	Path string `toml:"path"`
+	Hash string `toml:"hash"`
+	Size int64  `toml:"size"`
+}
+
+// LockRequirement is a requirement of a local package as it was when the lock was written
+type LockRequirement struct {
+	Name      string   `toml:"name"`
+	Specifier string   `toml:"specifier"`
+	Extras    []string `toml:"extras"`
+	Marker    string   `toml:"marker"`
+}
+
+// ReadLock parses the uv.lock in the given directory
+func ReadLock(dir string) (*Lock, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "uv.lock"))
+	if err != nil {
+		return nil, err
+	}
+
+	var lock Lock
+	if err := toml.Unmarshal(data, &lock); err != nil {
+		return nil, err
+	}
+	return &lock, nil
+}
+
+// Package returns the locked package with the given name, or the first one if the lock has
+// several versions of it for different platforms
+func (l *Lock) Package(name string) (*LockPackage, bool) {
+	name = NormalizeName(name)
+	for i := range l.Packages {
+		if NormalizeName(l.Packages[i].Name) == name {
+			return &l.Packages[i], true
+		}
+	}
+	return nil, false
+}
+
+// IsLocal reports whether the package is a project on disk rather than something downloaded
+func (p LockPackage) IsLocal() bool {
+	s := p.Source
+	return s.Editable != "" || s.Virtual != "" || s.Directory != "" || s.Path != ""
+}
+
+// Hashes returns the hashes of the package's sdist and wheels
+func (p LockPackage) Hashes() []string {
+	var hashes []string
+	if p.Sdist != nil && p.Sdist.Hash != "" {
+		hashes = append(hashes, p.Sdist.Hash)
+	}
+	for _, wheel := range p.Wheels {
+		if wheel.Hash != "" {
+			hashes = append(hashes, wheel.Hash)
+		}
+	}
+	return hashes
+}
+
+// nameSeparators are the runs of characters PEP 503 treats as the same
+var nameSeparators = regexp.MustCompile(`[-_.]+`)
+
+// NormalizeName returns a package name in PEP 503 form, so "Foo_Bar" and "foo-bar" compare equal
+func NormalizeName(name string) string {
+	return nameSeparators.ReplaceAllString(strings.ToLower(strings.TrimSpace(name)), "-")
+}
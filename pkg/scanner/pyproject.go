@@ -0,0 +1,108 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// Pyproject holds the parts of pyproject.toml that tuv reads
+type Pyproject struct {
+	Project struct {
+		Name           string `toml:"name"`
+		Version        string `toml:"version"`
+		RequiresPython string `toml:"requires-python"`
+		// License is an SPDX expression, or a {text = ...} or {file = ...} table in older projects
+		License              any                 `toml:"license"`
+		Dependencies         []string            `toml:"dependencies"`
+		OptionalDependencies map[string][]string `toml:"optional-dependencies"`
+		Scripts              map[string]string   `toml:"scripts"`
+	} `toml:"project"`
+	// DependencyGroups holds PEP 735 groups, whose entries are requirement strings or {include-group = "name"} tables
+	DependencyGroups map[string][]any `toml:"dependency-groups"`
+	Tool             struct {
+		Tuv struct {
+			Tasks map[string]string `toml:"tasks"`
+		} `toml:"tuv"`
+		UV struct {
+			DevDependencies []string `toml:"dev-dependencies"`
+			Workspace       *struct {
+				Members []string `toml:"members"`
+				Exclude []string `toml:"exclude"`
+			} `toml:"workspace"`
+		} `toml:"uv"`
+	} `toml:"tool"`
+}
+
+// ReadPyproject parses the project's pyproject.toml
+func ReadPyproject(projectPath string) (*Pyproject, error) {
+	data, err := os.ReadFile(filepath.Join(projectPath, "pyproject.toml"))
+	if err != nil {
+		return nil, err
+	}
+
+	var pyproject Pyproject
+	if err := toml.Unmarshal(data, &pyproject); err != nil {
+		return nil, err
+	}
+	return &pyproject, nil
+}
+
+// Runnable is a project script or tuv task that can be started with uv run
+type Runnable struct {
+	Name    string
+	Command string
+	IsTask  bool
+}
+
+// Runnables returns the project's [project.scripts] entry points followed by its [tool.tuv.tasks], each sorted by name
+func (p *Pyproject) Runnables() []Runnable {
+	var scripts, tasks []Runnable
+	for name, entry := range p.Project.Scripts {
+		scripts = append(scripts, Runnable{Name: name, Command: entry})
+	}
+	for name, command := range p.Tool.Tuv.Tasks {
+		tasks = append(tasks, Runnable{Name: name, Command: command, IsTask: true})
+	}
+
+	sort.Slice(scripts, func(i, j int) bool { return scripts[i].Name < scripts[j].Name })
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].Name < tasks[j].Name })
+	return append(scripts, tasks...)
+}
+
+// Groups returns each dependency group with its included groups expanded. uv's older
+// [tool.uv] dev-dependencies are merged into the "dev" group, as uv does.
+func (p *Pyproject) Groups() map[string][]string {
+	groups := make(map[string][]string)
+	for name := range p.DependencyGroups {
+		groups[name] = p.group(name, nil)
+	}
+	if len(p.Tool.UV.DevDependencies) > 0 {
+		groups["dev"] = append(groups["dev"], p.Tool.UV.DevDependencies...)
+	}
+	return groups
+}
+
+// group expands one dependency group, skipping includes already being expanded so a cycle can't recurse forever
+func (p *Pyproject) group(name string, seen []string) []string {
+	if slices.Contains(seen, name) {
+		return nil
+	}
+	seen = append(seen, name)
+
+	var requirements []string
+	for _, entry := range p.DependencyGroups[name] {
+		switch entry := entry.(type) {
+		case string:
+			requirements = append(requirements, entry)
+		case map[string]any:
+			if include, ok := entry["include-group"].(string); ok {
+				requirements = append(requirements, p.group(include, seen)...)
+			}
+		}
+	}
+	return requirements
+}
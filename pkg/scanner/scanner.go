@@ -1,24 +1,35 @@
 package scanner
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 )
 
-// UVProject represents a uv Python project
+// UVProject represents a Python project, managed by uv or by the tool it was detected with
 type UVProject struct {
 	Name          string
 	Path          string
+	Tool          Tool
 	PythonVersion string
 	Size          int64
 	LastModified  time.Time
 	HasVenv       bool
 	HasLock       bool
+	Git           *GitStatus
+	// Drift is how the project has moved away from its uv.lock, or nil if it hasn't
+	Drift *Drift
+
+	// Workspace is the root of the uv workspace the project belongs to, which is its own path for the root
+	Workspace string
+	// Members are a workspace root's member projects
+	Members []UVProject
 }
 
 // Scanner scans directories for uv projects
@@ -33,8 +44,14 @@ func NewScanner(parentDir string) *Scanner {
 	}
 }
 
-// ScanProjects scans the parent directory for uv projects
+// ScanProjects scans the parent directory for Python projects
 func (s *Scanner) ScanProjects() ([]UVProject, error) {
+	return s.Scan(context.Background(), nil)
+}
+
+// Scan scans the parent directory for Python projects, stopping early if ctx is cancelled.
+// progress, if set, is called after each directory with the number done and the total.
+func (s *Scanner) Scan(ctx context.Context, progress func(done, total int)) ([]UVProject, error) {
 	var projects []UVProject
 
 	// Check if parent directory exists
@@ -48,59 +65,82 @@ func (s *Scanner) ScanProjects() ([]UVProject, error) {
 		return nil, err
 	}
 
-	for _, entry := range entries {
+	for i, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if progress != nil {
+			progress(i, len(entries))
+		}
 		if !entry.IsDir() {
 			continue
 		}
 
-		projectPath := filepath.Join(s.ParentDir, entry.Name())
-
-		// Check for .python-version file
-		pythonVersionPath := filepath.Join(projectPath, ".python-version")
-		_, hasPythonVersion := os.Stat(pythonVersionPath)
-
-		// Check for .venv directory
-		venvPath := filepath.Join(projectPath, ".venv")
-		_, hasVenv := os.Stat(venvPath)
+		if project, ok := ScanProject(filepath.Join(s.ParentDir, entry.Name())); ok {
+			projects = append(projects, project)
+		}
+	}
 
-		// Check for uv.lock file
-		uvLockPath := filepath.Join(projectPath, "uv.lock")
-		_, hasUVLock := os.Stat(uvLockPath)
+	// Members are listed under their workspace, not again on their own
+	var members []string
+	for _, project := range projects {
+		for _, member := range project.Members {
+			members = append(members, member.Path)
+		}
+	}
+	projects = slices.DeleteFunc(projects, func(p UVProject) bool {
+		return slices.Contains(members, p.Path)
+	})
 
-		// Check for pyproject.toml file
-		pyprojectPath := filepath.Join(projectPath, "pyproject.toml")
-		_, hasPyproject := os.Stat(pyprojectPath)
+	return projects, nil
+}
 
-		// If it has at least one of these files, consider it a uv project
-		if !os.IsNotExist(hasPythonVersion) || !os.IsNotExist(hasPyproject) || (!os.IsNotExist(hasVenv) && !os.IsNotExist(hasUVLock)) {
-			info, _ := entry.Info()
+// ScanProject inspects a single directory and reports whether it is a Python project
+func ScanProject(projectPath string) (UVProject, bool) {
+	info, err := os.Stat(projectPath)
+	if err != nil || !info.IsDir() {
+		return UVProject{}, false
+	}
 
-			// Get Python version
-			pythonVersion := "unknown"
-			if !os.IsNotExist(hasPythonVersion) {
-				if versionBytes, err := os.ReadFile(pythonVersionPath); err == nil {
-					pythonVersion = strings.TrimSpace(string(versionBytes))
-				}
-			}
+	tool, ok := Detect(projectPath)
+	if !ok {
+		return UVProject{}, false
+	}
 
-			// Calculate directory size
-			size, _ := getDirSize(projectPath)
-
-			project := UVProject{
-				Name:          entry.Name(),
-				Path:          projectPath,
-				PythonVersion: pythonVersion,
-				Size:          size,
-				LastModified:  info.ModTime(),
-				HasVenv:       !os.IsNotExist(hasVenv),
-				HasLock:       !os.IsNotExist(hasUVLock),
-			}
+	pythonVersionPath := filepath.Join(projectPath, ".python-version")
+	_, hasPythonVersion := os.Stat(pythonVersionPath)
+	_, hasVenv := os.Stat(filepath.Join(projectPath, ".venv"))
+	_, hasUVLock := os.Stat(filepath.Join(projectPath, "uv.lock"))
 
-			projects = append(projects, project)
+	// Get Python version
+	pythonVersion := "unknown"
+	if !os.IsNotExist(hasPythonVersion) {
+		if versionBytes, err := os.ReadFile(pythonVersionPath); err == nil {
+			pythonVersion = strings.TrimSpace(string(versionBytes))
 		}
 	}
 
-	return projects, nil
+	// Calculate directory size
+	size, _ := getDirSize(projectPath)
+
+	project := UVProject{
+		Name:          filepath.Base(projectPath),
+		Path:          projectPath,
+		Tool:          tool,
+		PythonVersion: pythonVersion,
+		Size:          size,
+		LastModified:  info.ModTime(),
+		HasVenv:       !os.IsNotExist(hasVenv),
+		HasLock:       !os.IsNotExist(hasUVLock),
+		Git:           ReadGitStatus(projectPath),
+	}
+	if tool == ToolUV {
+		if project.HasLock {
+			project.Drift = DetectDrift(projectPath, projectPath, true)
+		}
+		scanWorkspace(&project)
+	}
+	return project, true
 }
 
 // getDirSize calculates the total size of a directory in bytes
@@ -150,8 +190,21 @@ func FormatSize(size int64) string {
 
 // RunUVCommand runs a uv command in the specified project directory
 func RunUVCommand(projectPath string, args ...string) (string, error) {
-	cmd := exec.Command("uv", args...)
+	return RunUVCommandContext(context.Background(), projectPath, args...)
+}
+
+// RunUVCommandContext runs a uv command in the specified project directory, killing it if ctx is cancelled
+func RunUVCommandContext(ctx context.Context, projectPath string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "uv", args...)
 	cmd.Dir = projectPath
 	output, err := cmd.CombinedOutput()
 	return string(output), err
 }
+
+// runGit runs a git command in the specified project directory
+func runGit(projectPath string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = projectPath
+	output, err := cmd.Output()
+	return string(output), err
+}
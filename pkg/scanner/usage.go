@@ -0,0 +1,125 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// UsageEntry is the size of one file or directory inside a project
+type UsageEntry struct {
+	Name  string
+	Path  string
+	Size  int64
+	IsDir bool
+}
+
+// DirUsage returns the sizes of the immediate children of path, largest first
+func DirUsage(path string) ([]UsageEntry, error) {
+	children, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []UsageEntry
+	for _, child := range children {
+		childPath := filepath.Join(path, child.Name())
+		entry := UsageEntry{Name: child.Name(), Path: childPath, IsDir: child.IsDir()}
+		if child.IsDir() {
+			entry.Size, _ = getDirSize(childPath)
+		} else if info, err := child.Info(); err == nil {
+			entry.Size = info.Size()
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Size > entries[j].Size
+	})
+	return entries, nil
+}
+
+// SitePackages returns the site-packages directory of the project's venv, or "" if there is none
+func SitePackages(projectPath string) string {
+	venv := filepath.Join(projectPath, ".venv")
+	candidates, _ := filepath.Glob(filepath.Join(venv, "lib", "python*", "site-packages"))
+	candidates = append(candidates, filepath.Join(venv, "Lib", "site-packages"))
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// PackageUsage returns the installed packages in the project's venv with their sizes, largest first.
+// Each package's size covers the top-level modules and data listed in its RECORD file.
+func PackageUsage(projectPath string) ([]UsageEntry, error) {
+	sitePackages := SitePackages(projectPath)
+	if sitePackages == "" {
+		return nil, nil
+	}
+
+	distInfos, err := filepath.Glob(filepath.Join(sitePackages, "*.dist-info"))
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []UsageEntry
+	for _, distInfo := range distInfos {
+		name := distName(filepath.Base(distInfo))
+		var size int64
+		for _, topLevel := range recordTopLevel(distInfo) {
+			path := filepath.Join(sitePackages, topLevel)
+			info, err := os.Stat(path)
+			if err != nil {
+				continue
+			}
+			if info.IsDir() {
+				dirSize, _ := walkSize(path)
+				size += dirSize
+			} else {
+				size += info.Size()
+			}
+		}
+		entries = append(entries, UsageEntry{Name: name, Path: distInfo, Size: size})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Size > entries[j].Size
+	})
+	return entries, nil
+}
+
+// distName extracts the distribution name from a "name-version.dist-info" directory name
+func distName(dirName string) string {
+	base := strings.TrimSuffix(dirName, ".dist-info")
+	if i := strings.LastIndex(base, "-"); i > 0 {
+		return base[:i]
+	}
+	return base
+}
+
+// recordTopLevel returns the distinct top-level paths listed in a dist-info RECORD file
+func recordTopLevel(distInfo string) []string {
+	data, err := os.ReadFile(filepath.Join(distInfo, "RECORD"))
+	if err != nil {
+		return []string{filepath.Base(distInfo)}
+	}
+
+	seen := make(map[string]bool)
+	var topLevel []string
+	for _, line := range strings.Split(string(data), "\n") {
+		path, _, _ := strings.Cut(line, ",")
+		if path == "" || strings.HasPrefix(path, "..") {
+			continue
+		}
+		first, _, _ := strings.Cut(path, "/")
+		if !seen[first] {
+			seen[first] = true
+			topLevel = append(topLevel, first)
+		}
+	}
+	return topLevel
+}
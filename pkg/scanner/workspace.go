@@ -0,0 +1,118 @@
+package scanner
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// IsWorkspaceRoot reports whether the project is the root of a uv workspace
+func (p UVProject) IsWorkspaceRoot() bool {
+	return p.Workspace != "" && p.Workspace == p.Path
+}
+
+// IsWorkspaceMember reports whether the project is a member of another project's workspace
+func (p UVProject) IsWorkspaceMember() bool {
+	return p.Workspace != "" && p.Workspace != p.Path
+}
+
+// WorkspaceMembers resolves the member globs of a [tool.uv.workspace] table to member directories,
+// leaving out excluded paths and directories without a pyproject.toml
+func WorkspaceMembers(root string, pyproject *Pyproject) []string {
+	workspace := pyproject.Tool.UV.Workspace
+	if workspace == nil {
+		return nil
+	}
+
+	var excluded []string
+	for _, pattern := range workspace.Exclude {
+		excluded = append(excluded, globDirs(root, pattern)...)
+	}
+
+	var members []string
+	for _, pattern := range workspace.Members {
+		for _, match := range globDirs(root, pattern) {
+			if match == root || slices.Contains(excluded, match) || slices.Contains(members, match) {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(match, "pyproject.toml")); err != nil {
+				continue
+			}
+			members = append(members, match)
+		}
+	}
+	slices.Sort(members)
+	return members
+}
+
+// globDirs returns the directories below root that match a workspace glob. Like uv's globs, a **
+// segment matches any number of directories; it doesn't descend into symlinks, .git, .venv or
+// node_modules.
+func globDirs(root, pattern string) []string {
+	var matches []string
+	var walk func(dir string, parts []string)
+	walk = func(dir string, parts []string) {
+		if len(parts) == 0 {
+			if !slices.Contains(matches, dir) {
+				matches = append(matches, dir)
+			}
+			return
+		}
+		part, rest := parts[0], parts[1:]
+		switch part {
+		case "", ".":
+			walk(dir, rest)
+			return
+		case "**":
+			walk(dir, rest)
+			entries, _ := os.ReadDir(dir)
+			for _, entry := range entries {
+				switch entry.Name() {
+				case ".git", ".venv", "node_modules":
+					continue
+				}
+				if entry.IsDir() {
+					walk(filepath.Join(dir, entry.Name()), parts)
+				}
+			}
+			return
+		}
+
+		entries, _ := os.ReadDir(dir)
+		for _, entry := range entries {
+			if ok, _ := path.Match(part, entry.Name()); !ok {
+				continue
+			}
+			next := filepath.Join(dir, entry.Name())
+			if info, err := os.Stat(next); err == nil && info.IsDir() {
+				walk(next, rest)
+			}
+		}
+	}
+	walk(root, strings.Split(filepath.ToSlash(pattern), "/"))
+	return matches
+}
+
+// scanWorkspace fills in a workspace root's members. They share the root's lock file and venv.
+func scanWorkspace(root *UVProject) {
+	pyproject, err := ReadPyproject(root.Path)
+	if err != nil || pyproject.Tool.UV.Workspace == nil {
+		return
+	}
+
+	root.Workspace = root.Path
+	for _, path := range WorkspaceMembers(root.Path, pyproject) {
+		member, ok := ScanProject(path)
+		if !ok {
+			continue
+		}
+		member.Workspace = root.Path
+		member.HasLock = root.HasLock
+		if root.HasLock {
+			member.Drift = DetectDrift(path, root.Path, false)
+		}
+		root.Members = append(root.Members, member)
+	}
+}
@@ -0,0 +1,69 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestWorkspaceMembers(t *testing.T) {
+	projects := []string{
+		"packages/a",
+		"packages/b",
+		"packages/nested/c",
+		"packages/nested/deeper/d",
+		"packages/.venv/e",
+		"libs/f",
+		"libs/skip",
+	}
+	tests := []struct {
+		name      string
+		workspace string
+		want      []string
+	}{
+		{name: "one level", workspace: `members = ["packages/*"]`, want: []string{"packages/a", "packages/b"}},
+		{
+			name:      "recursive",
+			workspace: `members = ["packages/**"]`,
+			want:      []string{"packages/a", "packages/b", "packages/nested/c", "packages/nested/deeper/d"},
+		},
+		{name: "recursive then name", workspace: `members = ["**/c"]`, want: []string{"packages/nested/c"}},
+		{
+			name:      "recursive exclude",
+			workspace: "members = [\"packages/**\", \"libs/*\"]\nexclude = [\"packages/nested/**\", \"libs/skip\"]",
+			want:      []string{"libs/f", "packages/a", "packages/b"},
+		},
+		{name: "literal path", workspace: `members = ["libs/f", "./packages/a"]`, want: []string{"libs/f", "packages/a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			for _, project := range projects {
+				dir := filepath.Join(root, filepath.FromSlash(project))
+				if err := os.MkdirAll(dir, 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(dir, "pyproject.toml"), []byte("[project]\nname = \"x\"\n"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			pyprojectText := "[project]\nname = \"root\"\n\n[tool.uv.workspace]\n" + tt.workspace + "\n"
+			if err := os.WriteFile(filepath.Join(root, "pyproject.toml"), []byte(pyprojectText), 0644); err != nil {
+				t.Fatal(err)
+			}
+			pyproject, err := ReadPyproject(root)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var want []string
+			for _, member := range tt.want {
+				want = append(want, filepath.Join(root, filepath.FromSlash(member)))
+			}
+			if got := WorkspaceMembers(root, pyproject); !slices.Equal(got, want) {
+				t.Errorf("WorkspaceMembers = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package testrun
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Outcome is how a single test case ended
+type Outcome string
+
+const (
+	Passed  Outcome = "passed"
+	Failed  Outcome = "failed"
+	Errored Outcome = "error"
+	Skipped Outcome = "skipped"
+)
+
+// Case is one test from the report. Message and Details are only set for tests that did not pass.
+type Case struct {
+	Name     string        `json:"name"`
+	Class    string        `json:"class"`
+	Duration time.Duration `json:"duration"`
+	Outcome  Outcome       `json:"outcome"`
+	Message  string        `json:"message,omitempty"`
+	Details  string        `json:"details,omitempty"`
+}
+
+// ID returns the test's class and name, joined the way pytest prints node IDs
+func (c Case) ID() string {
+	if c.Class == "" {
+		return c.Name
+	}
+	return c.Class + "::" + c.Name
+}
+
+// Report summarises one test run
+type Report struct {
+	Finished time.Time     `json:"finished"`
+	Duration time.Duration `json:"duration"`
+	Passed   int           `json:"passed"`
+	Failed   int           `json:"failed"`
+	Errors   int           `json:"errors"`
+	Skipped  int           `json:"skipped"`
+	Cases    []Case        `json:"cases"`
+}
+
+// Total returns the number of tests that were collected
+func (r *Report) Total() int {
+	return r.Passed + r.Failed + r.Errors + r.Skipped
+}
+
+// OK reports whether nothing failed or errored
+func (r *Report) OK() bool {
+	return r.Failed == 0 && r.Errors == 0
+}
+
+// Problems returns the failed and errored cases
+func (r *Report) Problems() []Case {
+	var cases []Case
+	for _, c := range r.Cases {
+		if c.Outcome == Failed || c.Outcome == Errored {
+			cases = append(cases, c)
+		}
+	}
+	return cases
+}
+
+// Summary returns a one-line description such as "12 passed, 1 failed in 3.2s"
+func (r *Report) Summary() string {
+	var parts []string
+	for _, part := range []struct {
+		n     int
+		label string
+	}{{r.Passed, "passed"}, {r.Failed, "failed"}, {r.Errors, "errors"}, {r.Skipped, "skipped"}} {
+		if part.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", part.n, part.label))
+		}
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "no tests")
+	}
+	return fmt.Sprintf("%s in %s", strings.Join(parts, ", "), r.Duration.Round(10*time.Millisecond))
+}
+
+// junitResult is a failure, error or skip element
+type junitResult struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitCase struct {
+	Name      string       `xml:"name,attr"`
+	ClassName string       `xml:"classname,attr"`
+	Time      string       `xml:"time,attr"`
+	Failure   *junitResult `xml:"failure"`
+	Error     *junitResult `xml:"error"`
+	Skipped   *junitResult `xml:"skipped"`
+}
+
+type junitSuite struct {
+	Time  string      `xml:"time,attr"`
+	Cases []junitCase `xml:"testcase"`
+}
+
+// Parse reads a JUnit XML report. Both a bare <testsuite> root and a <testsuites> wrapper are accepted.
+func Parse(data []byte) (*Report, error) {
+	var root struct {
+		XMLName xml.Name
+		junitSuite
+		Suites []junitSuite `xml:"testsuite"`
+	}
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("parsing JUnit report: %w", err)
+	}
+
+	suites := root.Suites
+	switch root.XMLName.Local {
+	case "testsuite":
+		suites = []junitSuite{root.junitSuite}
+	case "testsuites":
+	default:
+		return nil, fmt.Errorf("parsing JUnit report: unexpected root element <%s>", root.XMLName.Local)
+	}
+
+	report := &Report{}
+	for _, suite := range suites {
+		report.Duration += parseSeconds(suite.Time)
+		for _, jc := range suite.Cases {
+			c := Case{Name: jc.Name, Class: jc.ClassName, Duration: parseSeconds(jc.Time), Outcome: Passed}
+			// An error during teardown can accompany a failure, so errors take precedence
+			switch {
+			case jc.Error != nil:
+				c.Outcome, c.Message, c.Details = Errored, jc.Error.Message, strings.TrimSpace(jc.Error.Text)
+				report.Errors++
+			case jc.Failure != nil:
+				c.Outcome, c.Message, c.Details = Failed, jc.Failure.Message, strings.TrimSpace(jc.Failure.Text)
+				report.Failed++
+			case jc.Skipped != nil:
+				c.Outcome, c.Message = Skipped, jc.Skipped.Message
+				report.Skipped++
+			default:
+				report.Passed++
+			}
+			report.Cases = append(report.Cases, c)
+		}
+	}
+	return report, nil
+}
+
+// parseSeconds converts a JUnit time attribute, treating anything unparsable as zero
+func parseSeconds(s string) time.Duration {
+	seconds, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,71 @@
+package testrun
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Results stores the last test report per project path
+type Results struct {
+	mu      sync.Mutex
+	path    string
+	Reports map[string]*Report `json:"reports"`
+}
+
+// LoadResults reads the results file, returning empty results if it does not exist yet
+func LoadResults(path string) (*Results, error) {
+	r := &Results{path: path, Reports: make(map[string]*Report)}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return r, nil
+	}
+	if err != nil {
+		return r, err
+	}
+	if err := json.Unmarshal(data, r); err != nil {
+		return r, err
+	}
+	if r.Reports == nil {
+		r.Reports = make(map[string]*Report)
+	}
+	return r, nil
+}
+
+// Set replaces the last report for a project and saves the results. Passing tests are
+// dropped from the stored copy to keep the file small.
+func (r *Results) Set(projectPath string, report *Report) error {
+	stored := *report
+	stored.Cases = nil
+	for _, c := range report.Cases {
+		if c.Outcome != Passed {
+			stored.Cases = append(stored.Cases, c)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Reports[projectPath] = &stored
+	return r.save()
+}
+
+// For returns the last report for a project, or nil if its tests have never been run
+func (r *Results) For(projectPath string) *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.Reports[projectPath]
+}
+
+// save writes the results to disk
+func (r *Results) save() error {
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, data, 0644)
+}
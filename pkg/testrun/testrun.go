@@ -0,0 +1,59 @@
+package testrun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/chloebubble/tuv/pkg/runner"
+)
+
+// Run runs the project's pytest suite through uv and parses the JUnit report it writes.
+// Failing tests are not an error; err is only set when pytest could not produce a report,
+// in which case output holds what it printed.
+func Run(ctx context.Context, dir string) (report *Report, output string, err error) {
+	tmp, err := os.MkdirTemp("", "tuv-pytest-")
+	if err != nil {
+		return nil, "", err
+	}
+	defer os.RemoveAll(tmp)
+	xmlPath := filepath.Join(tmp, "junit.xml")
+
+	cmd := exec.CommandContext(ctx, "uv", "run", "pytest", "--junitxml="+xmlPath, "-o", "junit_family=xunit2")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), runner.DotEnv(dir)...)
+
+	started := time.Now()
+	out, runErr := cmd.CombinedOutput()
+	output = string(out)
+	if ctx.Err() != nil {
+		return nil, output, ctx.Err()
+	}
+
+	var exitErr *exec.ExitError
+	if runErr != nil && !errors.As(runErr, &exitErr) {
+		return nil, output, runErr
+	}
+
+	data, err := os.ReadFile(xmlPath)
+	if err != nil {
+		if runErr != nil {
+			return nil, output, fmt.Errorf("pytest exited with code %d", exitErr.ExitCode())
+		}
+		return nil, output, errors.New("pytest did not write a JUnit report")
+	}
+
+	report, err = Parse(data)
+	if err != nil {
+		return nil, output, err
+	}
+	report.Finished = time.Now()
+	if report.Duration == 0 {
+		report.Duration = time.Since(started)
+	}
+	return report, output, nil
+}
@@ -0,0 +1,169 @@
+package theme
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Auto picks the light or dark theme to match the terminal background
+const Auto = "auto"
+
+// Theme is a named set of colors. Colors are hex strings or ANSI color numbers;
+// an empty color leaves the terminal's own color in place.
+type Theme struct {
+	Name       string `yaml:"name"`
+	Base       string `yaml:"base,omitempty"`
+	Primary    string `yaml:"primary"`
+	Secondary  string `yaml:"secondary"`
+	Accent     string `yaml:"accent"`
+	Warning    string `yaml:"warning"`
+	Error      string `yaml:"error"`
+	Text       string `yaml:"text"`
+	DimText    string `yaml:"dim_text"`
+	Highlight  string `yaml:"highlight"`
+	Background string `yaml:"background"`
+}
+
+// Dark is the default theme
+var Dark = Theme{
+	Name:       "dark",
+	Primary:    "#7D56F4",
+	Secondary:  "#AE88FD",
+	Accent:     "#43BF6D",
+	Warning:    "#F2B705",
+	Error:      "#F25757",
+	Text:       "#FFFFFF",
+	DimText:    "#CCCCCC",
+	Highlight:  "#FF79C6",
+	Background: "#1A1B26",
+}
+
+// Light suits terminals with a light background
+var Light = Theme{
+	Name:       "light",
+	Primary:    "#5A3FC0",
+	Secondary:  "#7B5CD6",
+	Accent:     "#1E8A44",
+	Warning:    "#A86F00",
+	Error:      "#C62828",
+	Text:       "#1A1B26",
+	DimText:    "#555555",
+	Highlight:  "#C2185B",
+	Background: "#FAFAFA",
+}
+
+// HighContrast uses the basic ANSI palette at full brightness
+var HighContrast = Theme{
+	Name:       "high-contrast",
+	Primary:    "12",
+	Secondary:  "14",
+	Accent:     "10",
+	Warning:    "11",
+	Error:      "9",
+	Text:       "15",
+	DimText:    "15",
+	Highlight:  "13",
+	Background: "0",
+}
+
+// NoColor leaves every color to the terminal, for NO_COLOR
+var NoColor = Theme{Name: "no-color"}
+
+// BuiltIn lists the themes that ship with tuv
+var BuiltIn = []Theme{Dark, Light, HighContrast, NoColor}
+
+// Load reads the user themes in dir, one YAML file per theme. A missing directory has no themes.
+// Colors left out of a file are taken from its base theme, or from the dark theme.
+func Load(dir string) ([]Theme, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	more, _ := filepath.Glob(filepath.Join(dir, "*.yml"))
+	files = append(files, more...)
+	sort.Strings(files)
+
+	var themes []Theme
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return themes, err
+		}
+		var t Theme
+		if err := yaml.Unmarshal(data, &t); err != nil {
+			return themes, fmt.Errorf("%s: %w", filepath.Base(file), err)
+		}
+		if t.Name == "" {
+			t.Name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		}
+
+		base := Dark
+		if t.Base != "" {
+			found, ok := find(BuiltIn, t.Base)
+			if !ok {
+				return themes, fmt.Errorf("%s: unknown base theme %q", filepath.Base(file), t.Base)
+			}
+			base = found
+		}
+		themes = append(themes, t.inherit(base))
+	}
+	return themes, nil
+}
+
+// Available returns the built-in themes followed by the user themes in dir
+func Available(dir string) ([]Theme, error) {
+	user, err := Load(dir)
+	return append(append([]Theme{}, BuiltIn...), user...), err
+}
+
+// Resolve picks the theme to use. NO_COLOR always wins; "auto" or an empty name follows
+// the terminal background, using darkBackground to find out.
+func Resolve(name string, themes []Theme, darkBackground func() bool) (Theme, error) {
+	if os.Getenv("NO_COLOR") != "" {
+		return NoColor, nil
+	}
+	if name == "" || name == Auto {
+		if darkBackground() {
+			return Dark, nil
+		}
+		return Light, nil
+	}
+	if t, ok := find(themes, name); ok {
+		return t, nil
+	}
+	return Dark, fmt.Errorf("unknown theme %q", name)
+}
+
+// find looks a theme up by name, preferring later entries so user themes can replace built-ins
+func find(themes []Theme, name string) (Theme, bool) {
+	for i := len(themes) - 1; i >= 0; i-- {
+		if themes[i].Name == name {
+			return themes[i], true
+		}
+	}
+	return Theme{}, false
+}
+
+// inherit fills the colors t leaves out from base
+func (t Theme) inherit(base Theme) Theme {
+	fill := func(c *string, from string) {
+		if *c == "" {
+			*c = from
+		}
+	}
+	fill(&t.Primary, base.Primary)
+	fill(&t.Secondary, base.Secondary)
+	fill(&t.Accent, base.Accent)
+	fill(&t.Warning, base.Warning)
+	fill(&t.Error, base.Error)
+	fill(&t.Text, base.Text)
+	fill(&t.DimText, base.DimText)
+	fill(&t.Highlight, base.Highlight)
+	fill(&t.Background, base.Background)
+	return t
+}
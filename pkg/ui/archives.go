@@ -0,0 +1,163 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/chloebubble/tuv/pkg/archive"
+	"github.com/chloebubble/tuv/pkg/jobs"
+	"github.com/chloebubble/tuv/pkg/scanner"
+)
+
+// archiveProject packs a project into the archive directory and removes the original
+func (m Model) archiveProject(project scanner.UVProject) (string, error) {
+	entry, err := archive.Create(project.Path, m.config.ArchiveDirectory, m.config.ArchiveExclude, project.PythonVersion, project.Size)
+	if err != nil {
+		return "", err
+	}
+	if err := m.scanner.DeleteProject(project.Path); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Archived to %s", entry.Path(m.config.ArchiveDirectory)), nil
+}
+
+// openArchives loads the archive list and shows it
+func (m Model) openArchives() (tea.Model, tea.Cmd) {
+	m.state = StateArchives
+	m.archiveCursor = 0
+	m.error = ""
+	m.listArchives()
+	return m, nil
+}
+
+// listArchives reads the archive directory in the background
+func (m *Model) listArchives() {
+	archiveDir := m.config.ArchiveDirectory
+	m.archivesPending = true
+	id := m.startJob("List archives", func(ctx context.Context, report jobs.Reporter) (tea.Msg, error) {
+		entries, err := archive.List(archiveDir)
+		if err != nil {
+			return nil, err
+		}
+		return archivesFoundMsg{entries}, nil
+	})
+	m.awaitJob(StateArchives, id)
+}
+
+// updateArchives handles updates in the archives state
+func (m Model) updateArchives(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.archivesPending && !key.Matches(msg, m.keyMap.Back) {
+			return m, nil
+		}
+
+		switch {
+		case key.Matches(msg, m.keyMap.Up):
+			if m.archiveCursor > 0 {
+				m.archiveCursor--
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Down):
+			if m.archiveCursor < len(m.archives)-1 {
+				m.archiveCursor++
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Select):
+			if len(m.archives) == 0 {
+				return m, nil
+			}
+			entry := m.archives[m.archiveCursor]
+			archiveDir := m.config.ArchiveDirectory
+			parentDir := m.config.ParentDirectory
+
+			m.startJob("Restore "+entry.Name, func(ctx context.Context, report jobs.Reporter) (tea.Msg, error) {
+				report(0, "Extracting")
+				path, err := archive.Restore(entry, archiveDir, parentDir)
+				if err != nil {
+					return nil, err
+				}
+
+				status := fmt.Sprintf("Restored %s", entry.Name)
+				if _, err := os.Stat(filepath.Join(path, "pyproject.toml")); err == nil {
+					report(0.5, "Syncing")
+					if output, err := scanner.SyncProject(ctx, path); err != nil {
+						status += " but uv sync failed: " + failureSummary(err.Error(), output)
+					} else {
+						status += " and synced its environment"
+					}
+				}
+				return archiveRestoredMsg{status}, nil
+			})
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Back):
+			m.state = StateMainMenu
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
+
+// viewArchives renders the list of archived projects
+func (m Model) viewArchives() string {
+	var b strings.Builder
+
+	b.WriteString(GetCompactLogo() + "\n")
+
+	title := TitleStyle.Render("Archives")
+	b.WriteString(title + "\n")
+
+	divider := lipgloss.NewStyle().
+		Foreground(secondaryColor).
+		Render("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	b.WriteString(divider + "\n\n")
+
+	if m.archivesPending {
+		b.WriteString(FancyBoxStyle.Render(fmt.Sprintf("%s Loading archives...", m.spinner.View())) + "\n\n")
+	} else if len(m.archives) == 0 {
+		emptyMsg := fmt.Sprintf("No archived projects in %s.", m.config.ArchiveDirectory)
+		b.WriteString(FancyBoxStyle.Render(emptyMsg) + "\n\n")
+	} else {
+		var rows []string
+		for i, entry := range m.archives {
+			row := fmt.Sprintf("%-24s %s %10s", truncate(entry.Name, 24), entry.ArchivedAt.Format("2006-01-02"), scanner.FormatSize(entry.ArchiveSize))
+			if i == m.archiveCursor {
+				rows = append(rows, SelectedProjectStyle.Render(" > "+row))
+			} else {
+				rows = append(rows, ProjectStyle.Render("   "+row))
+			}
+		}
+		b.WriteString(ProjectListStyle.Render(strings.Join(rows, "\n")) + "\n\n")
+
+		entry := m.archives[m.archiveCursor]
+		var infoRows []string
+		infoRows = append(infoRows, InfoTitleStyle.Render("Original Path: ")+InfoValueStyle.Render(entry.OriginalPath))
+		infoRows = append(infoRows, InfoTitleStyle.Render("Python Version: ")+InfoValueStyle.Render(entry.PythonVersion))
+		infoRows = append(infoRows, InfoTitleStyle.Render("Original Size: ")+InfoValueStyle.Render(scanner.FormatSize(entry.OriginalSize)))
+		infoRows = append(infoRows, InfoTitleStyle.Render("Excluded: ")+InfoValueStyle.Render(strings.Join(entry.Excluded, ", ")))
+		b.WriteString(InfoStyle.Render(strings.Join(infoRows, "\n")) + "\n\n")
+	}
+
+	if !m.archivesPending && m.statusMsg != "" {
+		b.WriteString(StatusStyle.Render(m.statusMsg) + "\n")
+	}
+
+	if m.error != "" {
+		b.WriteString(ErrorStyle.Render("Error: "+m.error) + "\n")
+	}
+
+	help := m.helpView()
+	b.WriteString("\n" + help)
+
+	return BaseStyle.Render(b.String())
+}
@@ -0,0 +1,284 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/chloebubble/tuv/pkg/audit"
+	"github.com/chloebubble/tuv/pkg/jobs"
+	"github.com/chloebubble/tuv/pkg/scanner"
+)
+
+// auditRows is how many projects or findings the audit screen lists at once
+const auditRows = 10
+
+// auditState holds the audit results and which project's findings are open
+type auditState struct {
+	reports    []audit.Report
+	summary    audit.Summary
+	advisories int
+	pending    bool
+	cursor     int
+	// open is whether the highlighted project's findings are shown instead of the project list
+	open          bool
+	findingCursor int
+	back          AppState
+}
+
+// openAudit loads the local advisory database and audits every project's lock in the background
+func (m Model) openAudit() (tea.Model, tea.Cmd) {
+	back := m.state
+	if back == StateAudit {
+		back = m.audit.back
+	}
+	m.audit = auditState{pending: true, back: back}
+	m.state = StateAudit
+	m.error = ""
+
+	projects := m.projects
+	dir := m.config.AdvisoryDirectory
+	id := m.startJob("Audit locked packages", func(ctx context.Context, report jobs.Reporter) (tea.Msg, error) {
+		report(0, "loading advisories from "+dir)
+		db, err := audit.LoadDatabase(ctx, dir)
+		if err != nil {
+			return nil, err
+		}
+		reports, err := audit.Run(ctx, db, projects, func(done int, project scanner.UVProject) {
+			report(float64(done)/float64(len(projects)), project.Name)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return auditDoneMsg{reports: reports, advisories: db.Advisories}, nil
+	})
+	m.awaitJob(StateAudit, id)
+	return m, nil
+}
+
+// handleAuditDone shows a finished audit, or leaves the screen empty with the error if it failed
+func (m Model) handleAuditDone(msg auditDoneMsg) (tea.Model, tea.Cmd) {
+	m.audit.reports = msg.reports
+	m.audit.summary = audit.Summarize(msg.reports)
+	m.audit.advisories = msg.advisories
+	m.audit.pending = false
+	m.audit.cursor = 0
+	m.audit.open = false
+	return m, nil
+}
+
+// currentReport returns the highlighted project's audit report
+func (m Model) currentReport() (audit.Report, bool) {
+	if m.audit.cursor < 0 || m.audit.cursor >= len(m.audit.reports) {
+		return audit.Report{}, false
+	}
+	return m.audit.reports[m.audit.cursor], true
+}
+
+// canOpenReport reports whether the highlighted project has findings to show
+func (m Model) canOpenReport() bool {
+	report, ok := m.currentReport()
+	return ok && !m.audit.open && len(report.Findings) > 0
+}
+
+// updateAudit handles updates in the audit state
+func (m Model) updateAudit(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keyMap.Up):
+			if m.audit.open && m.audit.findingCursor > 0 {
+				m.audit.findingCursor--
+			} else if !m.audit.open && m.audit.cursor > 0 {
+				m.audit.cursor--
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Down):
+			if m.audit.open {
+				report, _ := m.currentReport()
+				if m.audit.findingCursor < len(report.Findings)-1 {
+					m.audit.findingCursor++
+				}
+			} else if m.audit.cursor < len(m.audit.reports)-1 {
+				m.audit.cursor++
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Select):
+			if m.canOpenReport() {
+				m.audit.open = true
+				m.audit.findingCursor = 0
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Scan):
+			if m.audit.pending {
+				return m, nil
+			}
+			return m.openAudit()
+
+		case key.Matches(msg, m.keyMap.Back):
+			if m.audit.open {
+				m.audit.open = false
+				return m, nil
+			}
+			m.state = m.audit.back
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
+
+// auditSeverityStyle returns the style findings of a severity are shown in
+func auditSeverityStyle(s audit.Severity) lipgloss.Style {
+	switch s {
+	case audit.SeverityCritical, audit.SeverityHigh:
+		return ErrorStyle
+	case audit.SeverityModerate:
+		return WarningStyle
+	default:
+		return StatusStyle
+	}
+}
+
+// fixedIn describes the releases that fix a finding
+func fixedIn(f audit.Finding) string {
+	if len(f.Fixed) == 0 {
+		return "no fix released"
+	}
+	return "fixed in " + strings.Join(f.Fixed, ", ")
+}
+
+// viewAudit renders the audit summary or the open project's findings
+func (m Model) viewAudit() string {
+	var b strings.Builder
+
+	title := "Vulnerability Audit"
+	if report, ok := m.currentReport(); ok && m.audit.open {
+		title += ": " + report.Project.Name
+	}
+	b.WriteString(TitleStyle.Render(title) + "\n")
+
+	divider := lipgloss.NewStyle().
+		Foreground(secondaryColor).
+		Render("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	b.WriteString(divider + "\n\n")
+
+	switch {
+	case m.audit.pending:
+		b.WriteString(FancyBoxStyle.Render(fmt.Sprintf("%s Auditing %d projects against %s...", m.spinner.View(), len(m.projects), m.config.AdvisoryDirectory)) + "\n\n")
+	case m.audit.reports == nil:
+		b.WriteString(FancyBoxStyle.Render(fmt.Sprintf("No audit results.\n\nPut OSV advisories (.json files or PyPI all.zip) in\n%s\nand press '%s' to audit again.", m.config.AdvisoryDirectory, m.keyMap.Scan.Help().Key)) + "\n\n")
+	case m.audit.open:
+		b.WriteString(m.viewAuditFindings())
+	default:
+		b.WriteString(m.viewAuditSummary())
+	}
+
+	if m.statusMsg != "" {
+		b.WriteString(StatusStyle.Render(m.statusMsg) + "\n\n")
+	}
+	if m.error != "" {
+		b.WriteString(ErrorStyle.Render("Error: "+m.error) + "\n\n")
+	}
+
+	help := m.helpView()
+	b.WriteString(help)
+
+	return BaseStyle.Render(b.String())
+}
+
+// viewAuditSummary renders the cross-project totals, each project's result and the most widespread advisories
+func (m Model) viewAuditSummary() string {
+	var b strings.Builder
+	summary := m.audit.summary
+
+	counts := fmt.Sprintf("%s findings in %s of %d audited projects (%d advisories)",
+		HighlightStyle.Render(fmt.Sprintf("%d", summary.Findings)),
+		HighlightStyle.Render(fmt.Sprintf("%d", summary.Affected)),
+		summary.Audited, m.audit.advisories)
+	var bySeverity []string
+	for s := audit.SeverityCritical; s >= audit.SeverityUnknown; s-- {
+		if n := summary.BySeverity[s]; n > 0 {
+			bySeverity = append(bySeverity, auditSeverityStyle(s).Render(fmt.Sprintf("%d %s", n, s)))
+		}
+	}
+	if len(bySeverity) > 0 {
+		counts += "\n" + strings.Join(bySeverity, " • ")
+	}
+	b.WriteString(counts + "\n\n")
+
+	start, end := listWindow(m.audit.cursor, len(m.audit.reports), auditRows)
+	var rows []string
+	for i := start; i < end; i++ {
+		report := m.audit.reports[i]
+		var result string
+		switch {
+		case report.Skipped != "":
+			result = "skipped, " + report.Skipped
+		case len(report.Findings) == 0:
+			result = fmt.Sprintf("✓ %d packages clean", report.Packages)
+		default:
+			result = fmt.Sprintf("✗ %d findings, worst %s", len(report.Findings), report.Worst())
+		}
+		row := fmt.Sprintf("%-24s %s", truncate(report.Project.Name, 24), result)
+		if i == m.audit.cursor {
+			rows = append(rows, SelectedProjectStyle.Render(" > "+row))
+		} else {
+			rows = append(rows, ProjectStyle.Render("   "+row))
+		}
+	}
+	if len(m.audit.reports) > auditRows {
+		rows = append(rows, StatusStyle.Render(fmt.Sprintf("   %d-%d of %d projects", start+1, end, len(m.audit.reports))))
+	}
+	b.WriteString(ProjectListStyle.Render(strings.Join(rows, "\n")) + "\n\n")
+
+	if len(summary.Advisories) > 0 {
+		var lines []string
+		for _, use := range summary.Advisories[:min(len(summary.Advisories), 5)] {
+			lines = append(lines, fmt.Sprintf("%s %s %s %s, %s (%d projects)",
+				auditSeverityStyle(use.Severity).Render(use.Advisory.ID), use.Package, use.Version,
+				StatusStyle.Render(use.Severity.String()), fixedIn(use.Finding), len(use.Projects)))
+		}
+		b.WriteString(InputLabelStyle.Render("Most widespread") + "\n" + strings.Join(lines, "\n") + "\n\n")
+	}
+	return b.String()
+}
+
+// viewAuditFindings renders the open project's findings with details of the highlighted one
+func (m Model) viewAuditFindings() string {
+	var b strings.Builder
+	report, _ := m.currentReport()
+
+	start, end := listWindow(m.audit.findingCursor, len(report.Findings), auditRows)
+	var rows []string
+	for i := start; i < end; i++ {
+		finding := report.Findings[i]
+		row := fmt.Sprintf("%-9s %-20s %s %s", finding.Severity, truncate(finding.Advisory.ID, 20), finding.Package, finding.Version)
+		if i == m.audit.findingCursor {
+			rows = append(rows, SelectedProjectStyle.Render(" > "+row))
+		} else {
+			rows = append(rows, ProjectStyle.Render("   "+row))
+		}
+	}
+	if len(report.Findings) > auditRows {
+		rows = append(rows, StatusStyle.Render(fmt.Sprintf("   %d-%d of %d findings", start+1, end, len(report.Findings))))
+	}
+	b.WriteString(ProjectListStyle.Render(strings.Join(rows, "\n")) + "\n\n")
+
+	finding := report.Findings[m.audit.findingCursor]
+	detail := []string{
+		auditSeverityStyle(finding.Severity).Render(finding.Advisory.ID) + " " + finding.Advisory.Summary,
+		fmt.Sprintf("%s %s, %s", finding.Package, finding.Version, fixedIn(finding)),
+	}
+	if len(finding.Advisory.Aliases) > 0 {
+		detail = append(detail, StatusStyle.Render("Also known as "+strings.Join(finding.Advisory.Aliases, ", ")))
+	}
+	b.WriteString(strings.Join(detail, "\n") + "\n\n")
+	return b.String()
+}
@@ -0,0 +1,488 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/chloebubble/tuv/pkg/jobs"
+	"github.com/chloebubble/tuv/pkg/sbom"
+	"github.com/chloebubble/tuv/pkg/scanner"
+	"github.com/chloebubble/tuv/pkg/testrun"
+)
+
+// bulkAction is an operation that can be run across several projects.
+// Actions with open set hand the targets to their own screen instead of running directly.
+// Actions with shared set run once for all targets with the same non-empty key, which share the result.
+type bulkAction struct {
+	name        string
+	destructive bool
+	run         func(ctx context.Context, m Model, project scanner.UVProject) (string, error)
+	open        func(m Model, targets []scanner.UVProject) (tea.Model, tea.Cmd)
+	shared      func(project scanner.UVProject) string
+}
+
+// bulkActions lists the actions offered in the bulk action menu
+var bulkActions = []bulkAction{
+	{
+		name: "Sync",
+		run: func(ctx context.Context, _ Model, p scanner.UVProject) (string, error) {
+			return scanner.SyncProject(ctx, p.Path)
+		},
+	},
+	{
+		name: "Sync whole workspace",
+		run: func(ctx context.Context, _ Model, p scanner.UVProject) (string, error) {
+			if p.Workspace == "" {
+				return "", fmt.Errorf("%s is not part of a uv workspace", p.Name)
+			}
+			return scanner.SyncWorkspace(ctx, p.Workspace)
+		},
+		// A root and its members are one workspace, synced once
+		shared: func(p scanner.UVProject) string { return p.Workspace },
+	},
+	{
+		name: "Lock",
+		run: func(ctx context.Context, _ Model, p scanner.UVProject) (string, error) {
+			return scanner.LockProject(ctx, p.Path)
+		},
+	},
+	{
+		name: "Clean...",
+		open: Model.openClean,
+	},
+	{
+		name:        "Delete venv",
+		destructive: true,
+		run: func(_ context.Context, _ Model, p scanner.UVProject) (string, error) {
+			return "", scanner.DeleteVenv(p.Path)
+		},
+	},
+	{
+		name:        "Delete project",
+		destructive: true,
+		run: func(_ context.Context, m Model, p scanner.UVProject) (string, error) {
+			return "", m.scanner.DeleteProject(p.Path)
+		},
+	},
+	{
+		name:        "Archive",
+		destructive: true,
+		run: func(_ context.Context, m Model, p scanner.UVProject) (string, error) {
+			return m.archiveProject(p)
+		},
+	},
+	{
+		name: "Test",
+		run: func(ctx context.Context, m Model, p scanner.UVProject) (string, error) {
+			report, output, err := testrun.Run(ctx, p.Path)
+			if err != nil {
+				return output, err
+			}
+			if err := m.testResults.Set(p.Path, report); err != nil {
+				return "", err
+			}
+			if !report.OK() {
+				return "", errors.New(report.Summary())
+			}
+			return report.Summary(), nil
+		},
+	},
+	{
+		name: "Export requirements...",
+		open: Model.openExport,
+	},
+	{
+		name: "SBOM (CycloneDX)",
+		run: func(_ context.Context, _ Model, p scanner.UVProject) (string, error) {
+			return writeSBOM(p, sbom.CycloneDX)
+		},
+	},
+	{
+		name: "SBOM (SPDX)",
+		run: func(_ context.Context, _ Model, p scanner.UVProject) (string, error) {
+			return writeSBOM(p, sbom.SPDX)
+		},
+	},
+}
+
+// writeSBOM writes the project's SBOM into it, reading the lock from its workspace root if it has one
+func writeSBOM(p scanner.UVProject, format sbom.Format) (string, error) {
+	path, err := sbom.WriteFile(p.Path, p.Workspace, format)
+	if err != nil {
+		return "", err
+	}
+	return "wrote " + filepath.Base(path), nil
+}
+
+// bulkResult records the outcome of a bulk action on one project
+type bulkResult struct {
+	project scanner.UVProject
+	output  string
+	err     error
+}
+
+// selectedProjects returns the selected projects in list order
+func (m Model) selectedProjects() []scanner.UVProject {
+	var projects []scanner.UVProject
+	for _, project := range m.allProjects() {
+		if m.selected[project.Path] {
+			projects = append(projects, project)
+		}
+	}
+	return projects
+}
+
+// pruneSelection drops selected paths that are no longer in the project list
+func (m Model) pruneSelection() {
+	known := make(map[string]bool, len(m.projects))
+	for _, project := range m.allProjects() {
+		known[project.Path] = true
+	}
+	for path := range m.selected {
+		if !known[path] {
+			delete(m.selected, path)
+		}
+	}
+}
+
+// openBulkMenu opens the bulk action menu for the selection, or the highlighted project if nothing is selected
+func (m Model) openBulkMenu() (tea.Model, tea.Cmd) {
+	targets := m.selectedProjects()
+	if project, ok := m.currentProject(); ok && len(targets) == 0 {
+		targets = []scanner.UVProject{project}
+	}
+	if len(targets) == 0 {
+		return m, nil
+	}
+
+	m.bulkTargets = targets
+	m.bulkMenu = 0
+	m.error = ""
+	m.state = StateBulkMenu
+	return m, nil
+}
+
+// updateSelectFilter handles updates in the select-by-filter state
+func (m Model) updateSelectFilter(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.filterInput, cmd = m.filterInput.Update(msg)
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keyMap.Back):
+			m.state = StateProjectList
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Select):
+			pattern := strings.ToLower(strings.TrimSpace(m.filterInput.Value()))
+			if pattern == "" {
+				return m, nil
+			}
+
+			matched := 0
+			for _, project := range m.allProjects() {
+				if matchesFilter(strings.ToLower(project.Name), pattern) {
+					m.selected[project.Path] = true
+					matched++
+				}
+			}
+
+			m.statusMsg = fmt.Sprintf("Selected %d projects matching '%s'", matched, pattern)
+			m.state = StateProjectList
+			return m, nil
+		}
+	}
+
+	return m, cmd
+}
+
+// matchesFilter reports whether name matches a glob pattern, or contains it when it has no glob characters
+func matchesFilter(name, pattern string) bool {
+	if strings.ContainsAny(pattern, "*?[") {
+		ok, err := filepath.Match(pattern, name)
+		return err == nil && ok
+	}
+	return strings.Contains(name, pattern)
+}
+
+// updateBulkMenu handles updates in the bulk action menu state
+func (m Model) updateBulkMenu(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keyMap.Up):
+			if m.bulkMenu > 0 {
+				m.bulkMenu--
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Down):
+			if m.bulkMenu < len(bulkActions)-1 {
+				m.bulkMenu++
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Select):
+			if open := bulkActions[m.bulkMenu].open; open != nil {
+				return open(m, m.bulkTargets)
+			}
+			if bulkActions[m.bulkMenu].destructive {
+				m.state = StateBulkConfirm
+				return m, nil
+			}
+			return m.startBulk()
+
+		case key.Matches(msg, m.keyMap.Back):
+			m.state = StateProjectList
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
+
+// updateBulkConfirm handles updates in the bulk confirmation state
+func (m Model) updateBulkConfirm(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keyMap.Confirm):
+			return m.startBulk()
+
+		case key.Matches(msg, m.keyMap.Cancel), key.Matches(msg, m.keyMap.Back):
+			m.state = StateBulkMenu
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
+
+// updateBulkReport handles updates in the bulk report state
+func (m Model) updateBulkReport(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keyMap.Back), key.Matches(msg, m.keyMap.Select):
+			// Remaining projects keep running as background jobs
+			m.state = StateProjectList
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
+
+// startBulk queues the chosen bulk action as one background job per target
+func (m Model) startBulk() (tea.Model, tea.Cmd) {
+	action := bulkActions[m.bulkMenu]
+	m.bulkRun++
+	m.bulkAction = action
+	m.bulkResults = nil
+	m.state = StateBulkReport
+
+	run := m.bulkRun
+	for _, group := range bulkGroups(action, m.bulkTargets) {
+		project := group[0]
+		name := fmt.Sprintf("%s %s", action.name, project.Name)
+		if len(group) > 1 {
+			name = fmt.Sprintf("%s %s (%d projects)", action.name, filepath.Base(action.shared(project)), len(group))
+		}
+		m.startJob(name, func(ctx context.Context, _ jobs.Reporter) (tea.Msg, error) {
+			// Cancelled jobs still report so the summary adds up
+			var output string
+			err := ctx.Err()
+			if err == nil {
+				output, err = action.run(ctx, m, project)
+				if ctx.Err() != nil {
+					err = ctx.Err()
+				}
+			}
+			results := make([]bulkResult, len(group))
+			for i, target := range group {
+				results[i] = bulkResult{project: target, output: output, err: err}
+			}
+			return bulkStepMsg{run: run, results: results}, nil
+		})
+	}
+	return m, nil
+}
+
+// bulkGroups splits the targets into the runs an action needs: one per target, or one per shared
+// key for actions that cover several targets at once
+func bulkGroups(action bulkAction, targets []scanner.UVProject) [][]scanner.UVProject {
+	var groups [][]scanner.UVProject
+	index := make(map[string]int)
+	for _, target := range targets {
+		key := ""
+		if action.shared != nil {
+			key = action.shared(target)
+		}
+		if i, ok := index[key]; ok && key != "" {
+			groups[i] = append(groups[i], target)
+			continue
+		}
+		index[key] = len(groups)
+		groups = append(groups, []scanner.UVProject{target})
+	}
+	return groups
+}
+
+// bulkRunning reports whether the current bulk action still has projects to finish
+func (m Model) bulkRunning() bool {
+	return len(m.bulkResults) < len(m.bulkTargets)
+}
+
+// handleBulkStep records a finished project and rescans once every target is done
+func (m Model) handleBulkStep(msg bulkStepMsg) (tea.Model, tea.Cmd) {
+	if msg.run != m.bulkRun {
+		return m, nil
+	}
+	m.bulkResults = append(m.bulkResults, msg.results...)
+	if m.bulkRunning() {
+		return m, nil
+	}
+
+	failed := 0
+	for _, result := range m.bulkResults {
+		if result.err != nil {
+			failed++
+		}
+	}
+	status := fmt.Sprintf("%s: %d succeeded, %d failed", m.bulkAction.name, len(m.bulkResults)-failed, failed)
+
+	// Rescan so deleted projects and changed sizes are reflected
+	m.scanProjects(status)
+	return m, nil
+}
+
+// viewSelectFilter renders the select-by-filter prompt
+func (m Model) viewSelectFilter() string {
+	var b strings.Builder
+
+	title := TitleStyle.Render("Select Projects")
+	b.WriteString(title + "\n\n")
+
+	b.WriteString("Select every project whose name matches:\n\n")
+
+	input := InputStyle.Render(
+		InputLabelStyle.Render("Filter: ") + "\n" +
+			m.filterInput.View(),
+	)
+	b.WriteString(input + "\n\n")
+
+	help := m.helpView()
+	b.WriteString(help)
+
+	return BaseStyle.Render(b.String())
+}
+
+// viewBulkMenu renders the bulk action menu
+func (m Model) viewBulkMenu() string {
+	var b strings.Builder
+
+	title := TitleStyle.Render("Bulk Actions")
+	b.WriteString(title + "\n")
+
+	target := fmt.Sprintf("Applies to %s projects", HighlightStyle.Render(fmt.Sprintf("%d", len(m.bulkTargets))))
+	b.WriteString(target + "\n\n")
+
+	var menuRows []string
+	for i, action := range bulkActions {
+		if i == m.bulkMenu {
+			menuRows = append(menuRows, SelectedItemStyle.Render(fmt.Sprintf(" > %s", action.name)))
+		} else {
+			menuRows = append(menuRows, ItemStyle.Render(fmt.Sprintf("   %s", action.name)))
+		}
+	}
+	b.WriteString(MenuStyle.Render(strings.Join(menuRows, "\n")) + "\n\n")
+
+	help := m.helpView()
+	b.WriteString(help)
+
+	return BaseStyle.Render(b.String())
+}
+
+// viewBulkConfirm renders the confirmation prompt for destructive bulk actions
+func (m Model) viewBulkConfirm() string {
+	var b strings.Builder
+	action := bulkActions[m.bulkMenu]
+
+	title := TitleStyle.Render("Confirm " + action.name)
+	b.WriteString(title + "\n\n")
+
+	var names []string
+	for _, project := range m.bulkTargets {
+		names = append(names, "• "+project.Name)
+	}
+	warning := WarningStyle.Render(fmt.Sprintf("This will %s for %d projects and cannot be undone:", strings.ToLower(action.name), len(m.bulkTargets)))
+	b.WriteString(FancyBoxStyle.Render(warning+"\n\n"+strings.Join(names, "\n")) + "\n\n")
+
+	help := m.helpView()
+	b.WriteString(help)
+
+	return BaseStyle.Render(b.String())
+}
+
+// viewBulkReport renders the progress and per-project results of a bulk action
+func (m Model) viewBulkReport() string {
+	var b strings.Builder
+	action := m.bulkAction
+
+	title := TitleStyle.Render(action.name + " Report")
+	b.WriteString(title + "\n")
+
+	divider := lipgloss.NewStyle().
+		Foreground(secondaryColor).
+		Render("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	b.WriteString(divider + "\n\n")
+
+	succeeded, failed := 0, 0
+	var rows []string
+	for _, result := range m.bulkResults {
+		if result.err != nil {
+			failed++
+			rows = append(rows, ErrorStyle.Render("✗ ")+result.project.Name+": "+failureSummary(result.err.Error(), result.output))
+		} else {
+			succeeded++
+			rows = append(rows, SuccessStyle.Render("✓ ")+result.project.Name)
+		}
+	}
+
+	progress := fmt.Sprintf("%d/%d done • %s succeeded • %s failed",
+		len(m.bulkResults), len(m.bulkTargets),
+		SuccessStyle.Render(fmt.Sprintf("%d", succeeded)),
+		ErrorStyle.Render(fmt.Sprintf("%d", failed)))
+	if m.bulkRunning() {
+		progress = fmt.Sprintf("%s %s", m.spinner.View(), progress)
+	}
+	b.WriteString(progress + "\n\n")
+
+	if len(rows) > 0 {
+		b.WriteString(ProjectListStyle.Render(strings.Join(rows, "\n")) + "\n\n")
+	}
+
+	help := m.helpView()
+	b.WriteString(help)
+
+	return BaseStyle.Render(b.String())
+}
+
+// failureSummary returns the last non-empty line of the command output, falling back to the error text
+func failureSummary(errText, output string) string {
+	// uv prints its progress first and the reason it failed last
+	lines := strings.Split(output, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := strings.TrimSpace(lines[i]); line != "" {
+			return line
+		}
+	}
+	return errText
+}
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/chloebubble/tuv/pkg/scanner"
+)
+
+func TestBulkGroups(t *testing.T) {
+	root := scanner.UVProject{Name: "root", Workspace: "/ws"}
+	member := scanner.UVProject{Name: "member", Workspace: "/ws"}
+	other := scanner.UVProject{Name: "other", Workspace: "/other"}
+	single := scanner.UVProject{Name: "single"}
+	workspace := func(p scanner.UVProject) string { return p.Workspace }
+
+	tests := []struct {
+		name    string
+		shared  func(scanner.UVProject) string
+		targets []scanner.UVProject
+		want    [][]string
+	}{
+		{
+			name:    "one run per target",
+			targets: []scanner.UVProject{root, member, single},
+			want:    [][]string{{"root"}, {"member"}, {"single"}},
+		},
+		{
+			name:    "workspace members share a run",
+			shared:  workspace,
+			targets: []scanner.UVProject{root, other, member},
+			want:    [][]string{{"root", "member"}, {"other"}},
+		},
+		{
+			name:    "projects without a key run alone",
+			shared:  workspace,
+			targets: []scanner.UVProject{single, single},
+			want:    [][]string{{"single"}, {"single"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups := bulkGroups(bulkAction{shared: tt.shared}, tt.targets)
+			var got [][]string
+			for _, group := range groups {
+				var names []string
+				for _, p := range group {
+					names = append(names, p.Name)
+				}
+				got = append(got, names)
+			}
+			if !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("bulkGroups() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
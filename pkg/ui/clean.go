@@ -0,0 +1,242 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/chloebubble/tuv/pkg/jobs"
+	"github.com/chloebubble/tuv/pkg/scanner"
+)
+
+// cleanState holds the clean screen's targets, findings and choices
+type cleanState struct {
+	projects []scanner.UVProject
+	found    map[string][]scanner.CleanTarget
+	chosen   map[scanner.CleanCategory]bool
+	cursor   int
+	done     bool
+	freed    int64
+	failures []string
+	back     AppState
+	pending  bool
+	message  string
+}
+
+// openClean scans the given projects for reclaimable space and shows the clean screen
+func (m Model) openClean(projects []scanner.UVProject) (tea.Model, tea.Cmd) {
+	if len(projects) == 0 {
+		return m, nil
+	}
+
+	back := m.state
+	if back == StateBulkMenu {
+		back = StateProjectList
+	}
+
+	// Caches are safe to drop by default; the venv has to be opted into
+	chosen := make(map[scanner.CleanCategory]bool)
+	for _, category := range scanner.CleanCategories {
+		chosen[category] = category != scanner.CleanVenv
+	}
+
+	m.clean = cleanState{projects: projects, chosen: chosen, back: back, pending: true}
+	m.clean.message = fmt.Sprintf("Measuring reclaimable space in %d projects...", len(projects))
+	m.state = StateClean
+	m.error = ""
+
+	id := m.startJob("Measure reclaimable space", func(ctx context.Context, report jobs.Reporter) (tea.Msg, error) {
+		found := make(map[string][]scanner.CleanTarget)
+		for i, project := range projects {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			report(float64(i)/float64(len(projects)), project.Name)
+			targets, err := scanner.FindReclaimable(project.Path)
+			if err != nil {
+				return nil, err
+			}
+			found[project.Path] = targets
+		}
+		return cleanScanMsg{projects: projectPaths(projects), found: found}, nil
+	})
+	m.awaitJob(StateClean, id)
+	return m, nil
+}
+
+// cleanTotals returns the reclaimable bytes per category across all projects
+func (c cleanState) cleanTotals() map[scanner.CleanCategory]int64 {
+	totals := make(map[scanner.CleanCategory]int64)
+	for _, targets := range c.found {
+		for _, target := range targets {
+			totals[target.Category] += target.Size
+		}
+	}
+	return totals
+}
+
+// updateClean handles updates in the clean state
+func (m Model) updateClean(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.clean.pending {
+			if key.Matches(msg, m.keyMap.Back) {
+				m.state = m.clean.back
+			}
+			return m, nil
+		}
+
+		switch {
+		case key.Matches(msg, m.keyMap.Up):
+			if m.clean.cursor > 0 {
+				m.clean.cursor--
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Down):
+			if m.clean.cursor < len(scanner.CleanCategories)-1 {
+				m.clean.cursor++
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Toggle):
+			if !m.clean.done {
+				category := scanner.CleanCategories[m.clean.cursor]
+				m.clean.chosen[category] = !m.clean.chosen[category]
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Select):
+			if m.clean.done {
+				m.state = m.clean.back
+				return m, nil
+			}
+			return m.runClean()
+
+		case key.Matches(msg, m.keyMap.Back):
+			m.state = m.clean.back
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
+
+// runClean removes the chosen categories from every project on the clean screen
+func (m Model) runClean() (tea.Model, tea.Cmd) {
+	projects := m.clean.projects
+	found := m.clean.found
+	chosen := make(map[scanner.CleanCategory]bool)
+	for category, ok := range m.clean.chosen {
+		chosen[category] = ok
+	}
+
+	m.clean.pending = true
+	m.clean.message = "Cleaning..."
+
+	id := m.startJob("Clean "+pluralProjects(projects), func(ctx context.Context, report jobs.Reporter) (tea.Msg, error) {
+		var freed int64
+		var failures []string
+		for i, project := range projects {
+			if err := ctx.Err(); err != nil {
+				failures = append(failures, "cancelled before "+project.Name)
+				break
+			}
+			report(float64(i)/float64(len(projects)), project.Name)
+			var targets []scanner.CleanTarget
+			for _, target := range found[project.Path] {
+				if chosen[target.Category] {
+					targets = append(targets, target)
+				}
+			}
+			n, err := scanner.Clean(project.Path, targets)
+			freed += n
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", project.Name, err))
+			}
+		}
+		return cleanDoneMsg{projects: projectPaths(projects), freed: freed, failures: failures}, nil
+	})
+	m.awaitJob(StateClean, id)
+	return m, nil
+}
+
+// projectPaths returns the projects' paths, which tell which clean screen a result belongs to
+func projectPaths(projects []scanner.UVProject) []string {
+	paths := make([]string, len(projects))
+	for i, project := range projects {
+		paths[i] = project.Path
+	}
+	return paths
+}
+
+// pluralProjects names a single project, or counts several
+func pluralProjects(projects []scanner.UVProject) string {
+	if len(projects) == 1 {
+		return projects[0].Name
+	}
+	return fmt.Sprintf("%d projects", len(projects))
+}
+
+// viewClean renders the reclaimable space breakdown and clean results
+func (m Model) viewClean() string {
+	var b strings.Builder
+
+	title := "Clean " + m.clean.projects[0].Name
+	if len(m.clean.projects) > 1 {
+		title = fmt.Sprintf("Clean %d Projects", len(m.clean.projects))
+	}
+	b.WriteString(TitleStyle.Render(title) + "\n")
+
+	divider := lipgloss.NewStyle().
+		Foreground(secondaryColor).
+		Render("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	b.WriteString(divider + "\n\n")
+
+	if m.clean.pending {
+		b.WriteString(FancyBoxStyle.Render(fmt.Sprintf("%s %s", m.spinner.View(), m.clean.message)) + "\n\n")
+	} else if m.clean.done {
+		result := SuccessStyle.Render(fmt.Sprintf("Freed %s", scanner.FormatSize(m.clean.freed)))
+		for _, failure := range m.clean.failures {
+			result += "\n" + ErrorStyle.Render("✗ ") + failure
+		}
+		if m.clean.chosen[scanner.CleanVenv] {
+			result += "\n\n" + StatusStyle.Render("Removed venvs can be recreated with uv sync.")
+		}
+		b.WriteString(FancyBoxStyle.Render(result) + "\n\n")
+	} else {
+		totals := m.clean.cleanTotals()
+		var selected int64
+		var rows []string
+		for i, category := range scanner.CleanCategories {
+			mark := "[ ]"
+			if m.clean.chosen[category] {
+				mark = "[x]"
+				selected += totals[category]
+			}
+			row := fmt.Sprintf("%s %-42s %10s", mark, category.Label(), scanner.FormatSize(totals[category]))
+			if i == m.clean.cursor {
+				rows = append(rows, SelectedProjectStyle.Render(" > "+row))
+			} else {
+				rows = append(rows, ProjectStyle.Render("   "+row))
+			}
+		}
+		b.WriteString(ProjectListStyle.Render(strings.Join(rows, "\n")) + "\n\n")
+
+		summary := fmt.Sprintf("Reclaimable: %s", HighlightStyle.Render(scanner.FormatSize(selected)))
+		b.WriteString(summary + "\n")
+		b.WriteString(StatusStyle.Render("Virtual environments can be recreated later with uv sync.") + "\n\n")
+	}
+
+	if m.error != "" {
+		b.WriteString(ErrorStyle.Render("Error: "+m.error) + "\n\n")
+	}
+
+	help := m.helpView()
+	b.WriteString(help)
+
+	return BaseStyle.Render(b.String())
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/chloebubble/tuv/pkg/jobs"
+	"github.com/chloebubble/tuv/pkg/scanner"
+)
+
+// canResolveDrift reports whether the highlighted project has drifted from its uv.lock
+func (m Model) canResolveDrift() bool {
+	project, ok := m.currentProject()
+	return ok && project.Drift != nil
+}
+
+// resolveDrift relocks the project if needed and syncs its venv in the background
+func (m Model) resolveDrift(project scanner.UVProject) (tea.Model, tea.Cmd) {
+	if project.Drift == nil {
+		return m, nil
+	}
+	m.statusMsg = fmt.Sprintf("Bringing %s back in line with uv.lock...", project.Name)
+	m.error = ""
+
+	m.startJob("Resolve drift in "+project.Name, func(ctx context.Context, report jobs.Reporter) (tea.Msg, error) {
+		output, err := scanner.ResolveDrift(ctx, project.Path)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			return nil, errors.New(failureSummary(err.Error(), output))
+		}
+		// Members share their workspace's lock and venv, so the whole workspace is rescanned
+		root := cmp.Or(project.Workspace, project.Path)
+		updated, ok := scanner.ScanProject(root)
+		if !ok {
+			return nil, fmt.Errorf("%s is no longer a Python project", root)
+		}
+		return driftResolvedMsg{project: updated, name: project.Name}, nil
+	})
+	return m, nil
+}
+
+// handleDriftResolved replaces the rescanned project in the list
+func (m Model) handleDriftResolved(msg driftResolvedMsg) (tea.Model, tea.Cmd) {
+	for i := range m.projects {
+		if m.projects[i].Path == msg.project.Path {
+			m.projects[i] = msg.project
+		}
+	}
+	m.statusMsg = fmt.Sprintf("%s matches uv.lock again", msg.name)
+	return m, nil
+}
+
+// driftBadge renders the marker shown next to projects that have drifted from their uv.lock
+func driftBadge() string {
+	return WarningStyle.Render("[drift]")
+}
@@ -0,0 +1,331 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/chloebubble/tuv/pkg/jobs"
+	"github.com/chloebubble/tuv/pkg/scanner"
+)
+
+// exportState holds the export screen's targets and choices
+type exportState struct {
+	projects []scanner.UVProject
+	file     scanner.ExportFile
+	hashes   bool
+	// clipboard copies the export instead of writing it into the project, which needs a single project
+	clipboard bool
+	extras    []string
+	groups    []string
+	// chosen holds the ticked extras and groups, keyed by exportRow.key
+	chosen map[string]bool
+	cursor int
+	// replacing lists the existing files the export would overwrite while it waits for confirmation
+	replacing []string
+	pending   bool
+	done      bool
+	written   []string
+	failures  []string
+	back      AppState
+}
+
+// exportRow is one line of the export screen's option list
+type exportRow struct {
+	kind string
+	name string
+}
+
+// key identifies an extra or group row in exportState.chosen
+func (r exportRow) key() string {
+	return r.kind + ":" + r.name
+}
+
+// openExport shows the export options for the given projects
+func (m Model) openExport(projects []scanner.UVProject) (tea.Model, tea.Cmd) {
+	if len(projects) == 0 {
+		return m, nil
+	}
+
+	back := m.state
+	if back == StateBulkMenu {
+		back = StateProjectList
+	}
+
+	extras, groups := scanner.ExportChoices(projects)
+	m.export = exportState{projects: projects, hashes: true, extras: extras, groups: groups, chosen: make(map[string]bool), back: back}
+	m.state = StateExport
+	m.error = ""
+	return m, nil
+}
+
+// exportRows returns the option rows: the file, hashes, the clipboard for a single project, then each extra and group
+func (e exportState) exportRows() []exportRow {
+	rows := []exportRow{{kind: "file"}, {kind: "hashes"}}
+	if len(e.projects) == 1 {
+		rows = append(rows, exportRow{kind: "clipboard"})
+	}
+	for _, extra := range e.extras {
+		rows = append(rows, exportRow{kind: "extra", name: extra})
+	}
+	for _, group := range e.groups {
+		rows = append(rows, exportRow{kind: "group", name: group})
+	}
+	return rows
+}
+
+// toggleExportRow flips the highlighted option, cycling through the files on the file row
+func (m Model) toggleExportRow() Model {
+	row := m.export.exportRows()[m.export.cursor]
+	switch row.kind {
+	case "file":
+		m.export.file = scanner.ExportFiles[(int(m.export.file)+1)%len(scanner.ExportFiles)]
+		// A dev requirements file is empty without a group, so start from the dev group
+		devGroup := exportRow{kind: "group", name: "dev"}.key()
+		if m.export.file == scanner.DevRequirementsFile && len(m.chosenExport("group")) == 0 {
+			m.export.chosen[devGroup] = true
+		}
+	case "hashes":
+		m.export.hashes = !m.export.hashes
+	case "clipboard":
+		m.export.clipboard = !m.export.clipboard
+	default:
+		m.export.chosen[row.key()] = !m.export.chosen[row.key()]
+	}
+	return m
+}
+
+// chosenExport returns the ticked extras or groups
+func (m Model) chosenExport(kind string) []string {
+	var chosen []string
+	for _, row := range m.export.exportRows() {
+		if row.kind == kind && m.export.chosen[row.key()] {
+			chosen = append(chosen, row.name)
+		}
+	}
+	return chosen
+}
+
+// updateExport handles updates in the export state
+func (m Model) updateExport(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.export.pending {
+			if key.Matches(msg, m.keyMap.Back) {
+				m.state = m.export.back
+			}
+			return m, nil
+		}
+		if m.export.replacing != nil {
+			switch {
+			case key.Matches(msg, m.keyMap.Confirm):
+				return m.runExport()
+			case key.Matches(msg, m.keyMap.Cancel), key.Matches(msg, m.keyMap.Back):
+				m.export.replacing = nil
+			}
+			return m, nil
+		}
+
+		switch {
+		case key.Matches(msg, m.keyMap.Up):
+			if !m.export.done && m.export.cursor > 0 {
+				m.export.cursor--
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Down):
+			if !m.export.done && m.export.cursor < len(m.export.exportRows())-1 {
+				m.export.cursor++
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Toggle):
+			if !m.export.done {
+				m = m.toggleExportRow()
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Select):
+			if m.export.done {
+				m.state = m.export.back
+				return m, nil
+			}
+			if replacing := m.exportReplaces(); len(replacing) > 0 {
+				m.export.replacing = replacing
+				return m, nil
+			}
+			return m.runExport()
+
+		case key.Matches(msg, m.keyMap.Back):
+			m.state = m.export.back
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
+
+// exportReplaces returns the files the export would overwrite
+func (m Model) exportReplaces() []string {
+	if m.export.clipboard && len(m.export.projects) == 1 {
+		return nil
+	}
+	var existing []string
+	for _, project := range m.export.projects {
+		path := filepath.Join(project.Path, m.export.file.Filename())
+		if _, err := os.Stat(path); err == nil {
+			existing = append(existing, path)
+		}
+	}
+	return existing
+}
+
+// runExport exports every project on the export screen with the chosen options
+func (m Model) runExport() (tea.Model, tea.Cmd) {
+	projects := m.export.projects
+	opts := scanner.ExportOptions{
+		File:   m.export.file,
+		Hashes: m.export.hashes,
+		Extras: m.chosenExport("extra"),
+		Groups: m.chosenExport("group"),
+	}
+	toClipboard := m.export.clipboard && len(projects) == 1
+
+	m.export.pending = true
+	m.export.replacing = nil
+	id := m.startJob("Export "+opts.File.Filename()+" for "+pluralProjects(projects), func(ctx context.Context, report jobs.Reporter) (tea.Msg, error) {
+		var written, failures []string
+		for i, project := range projects {
+			if err := ctx.Err(); err != nil {
+				failures = append(failures, "cancelled before "+project.Name)
+				break
+			}
+			report(float64(i)/float64(len(projects)), project.Name)
+			contents, err := scanner.ExportRequirements(ctx, project.Path, opts)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %s", project.Name, failureSummary(err.Error(), contents)))
+				continue
+			}
+			if toClipboard {
+				if err := clipboard.WriteAll(contents); err != nil {
+					failures = append(failures, fmt.Sprintf("%s: copying to the clipboard failed: %v", project.Name, err))
+					continue
+				}
+				written = append(written, fmt.Sprintf("Copied %s for %s to the clipboard", opts.File.Filename(), project.Name))
+				continue
+			}
+			path := filepath.Join(project.Path, opts.File.Filename())
+			if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", project.Name, err))
+				continue
+			}
+			written = append(written, "Wrote "+path)
+		}
+		return exportDoneMsg{written: written, failures: failures}, nil
+	})
+	m.awaitJob(StateExport, id)
+	return m, nil
+}
+
+// handleExportDone shows the outcome of an export
+func (m Model) handleExportDone(msg exportDoneMsg) (tea.Model, tea.Cmd) {
+	m.export.pending = false
+	m.export.done = true
+	m.export.written = msg.written
+	m.export.failures = msg.failures
+	return m, nil
+}
+
+// exportRowLabel describes an option row with its current value
+func (m Model) exportRowLabel(row exportRow) string {
+	mark := func(on bool) string {
+		if on {
+			return "[x]"
+		}
+		return "[ ]"
+	}
+	switch row.kind {
+	case "file":
+		return fmt.Sprintf("File: %s", m.export.file.Filename())
+	case "hashes":
+		return mark(m.export.hashes) + " Include hashes"
+	case "clipboard":
+		return mark(m.export.clipboard) + " Copy to the clipboard instead of writing"
+	default:
+		return fmt.Sprintf("%s %s %s", mark(m.export.chosen[row.key()]), row.kind, row.name)
+	}
+}
+
+// viewExport renders the export options or their outcome
+func (m Model) viewExport() string {
+	var b strings.Builder
+
+	title := "Export " + m.export.projects[0].Name
+	if len(m.export.projects) > 1 {
+		title = fmt.Sprintf("Export %d Projects", len(m.export.projects))
+	}
+	b.WriteString(TitleStyle.Render(title) + "\n")
+
+	divider := lipgloss.NewStyle().
+		Foreground(secondaryColor).
+		Render("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	b.WriteString(divider + "\n\n")
+
+	switch {
+	case m.export.pending:
+		b.WriteString(FancyBoxStyle.Render(fmt.Sprintf("%s Exporting %s...", m.spinner.View(), m.export.file.Filename())) + "\n\n")
+	case m.export.done:
+		var lines []string
+		for _, line := range m.export.written {
+			lines = append(lines, SuccessStyle.Render("✓ ")+line)
+		}
+		for _, failure := range m.export.failures {
+			lines = append(lines, ErrorStyle.Render("✗ ")+failure)
+		}
+		b.WriteString(FancyBoxStyle.Render(strings.Join(lines, "\n")) + "\n\n")
+	case m.export.replacing != nil:
+		var names []string
+		for _, path := range m.export.replacing {
+			names = append(names, "• "+path)
+		}
+		warning := WarningStyle.Render("This will replace these existing files:")
+		b.WriteString(FancyBoxStyle.Render(warning+"\n\n"+strings.Join(names, "\n")) + "\n\n")
+	default:
+		var rows []string
+		for i, row := range m.export.exportRows() {
+			label := m.exportRowLabel(row)
+			if i == m.export.cursor {
+				rows = append(rows, SelectedProjectStyle.Render(" > "+label))
+			} else {
+				rows = append(rows, ProjectStyle.Render("   "+label))
+			}
+		}
+		b.WriteString(ProjectListStyle.Render(strings.Join(rows, "\n")) + "\n\n")
+
+		var note string
+		switch m.export.file {
+		case scanner.DevRequirementsFile:
+			note = "Only the ticked dependency groups are exported."
+		case scanner.ConstraintsFile:
+			note = "Pins every package except the project itself and paths or URLs, for pip install -c."
+		default:
+			note = "Exports the project's dependencies plus the ticked extras and groups."
+		}
+		b.WriteString(StatusStyle.Render(note) + "\n\n")
+	}
+
+	if m.error != "" {
+		b.WriteString(ErrorStyle.Render("Error: "+m.error) + "\n\n")
+	}
+
+	help := m.helpView()
+	b.WriteString(help)
+
+	return BaseStyle.Render(b.String())
+}
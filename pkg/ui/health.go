@@ -0,0 +1,292 @@
+package ui
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/chloebubble/tuv/pkg/health"
+	"github.com/chloebubble/tuv/pkg/jobs"
+	"github.com/chloebubble/tuv/pkg/scanner"
+)
+
+// healthRows is how many findings the dashboard lists at once
+const healthRows = 12
+
+// healthSort is the order findings are listed in on the health dashboard
+type healthSort int
+
+const (
+	sortBySeverity healthSort = iota
+	sortByProject
+	sortByCheck
+	healthSortCount
+)
+
+// String names the sort order for the dashboard header
+func (s healthSort) String() string {
+	switch s {
+	case sortByProject:
+		return "project"
+	case sortByCheck:
+		return "check"
+	default:
+		return "severity"
+	}
+}
+
+// healthState holds the health dashboard's findings and how they are listed
+type healthState struct {
+	findings []health.Finding
+	checked  int
+	pending  bool
+	cursor   int
+	sortBy   healthSort
+	back     AppState
+}
+
+// openHealth checks every project in the background and shows the health dashboard
+func (m Model) openHealth() (tea.Model, tea.Cmd) {
+	back := m.state
+	if back == StateHealth {
+		back = m.health.back
+	}
+	m.health = healthState{pending: true, sortBy: m.health.sortBy, back: back}
+	m.state = StateHealth
+	m.error = ""
+
+	projects := m.projects
+	id := m.startJob("Check project health", func(ctx context.Context, report jobs.Reporter) (tea.Msg, error) {
+		findings, err := health.Run(ctx, projects, time.Now(), func(done int, project scanner.UVProject) {
+			report(float64(done)/float64(len(projects)), project.Name)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return healthCheckedMsg{findings: findings, checked: len(projects)}, nil
+	})
+	m.awaitJob(StateHealth, id)
+	return m, nil
+}
+
+// sortFindings orders findings for the dashboard, most severe first within equal keys
+func sortFindings(findings []health.Finding, by healthSort) {
+	slices.SortStableFunc(findings, func(a, b health.Finding) int {
+		bySeverity := cmp.Compare(b.Severity, a.Severity)
+		byProject := cmp.Compare(a.Project.Name, b.Project.Name)
+		byCheck := cmp.Compare(checkIndex(a.Check), checkIndex(b.Check))
+		switch by {
+		case sortByProject:
+			return cmp.Or(byProject, bySeverity, byCheck)
+		case sortByCheck:
+			return cmp.Or(byCheck, bySeverity, byProject)
+		default:
+			return cmp.Or(bySeverity, byProject, byCheck)
+		}
+	})
+}
+
+// checkIndex returns a check's position in health.Checks, so checks sort in their natural order
+func checkIndex(name string) int {
+	return slices.IndexFunc(health.Checks, func(c health.Check) bool { return c.Name == name })
+}
+
+// currentFinding returns the highlighted finding
+func (m Model) currentFinding() (health.Finding, bool) {
+	if m.health.cursor < 0 || m.health.cursor >= len(m.health.findings) {
+		return health.Finding{}, false
+	}
+	return m.health.findings[m.health.cursor], true
+}
+
+// canFixFinding reports whether the highlighted finding has a fix
+func (m Model) canFixFinding() bool {
+	finding, ok := m.currentFinding()
+	return ok && finding.Fix != nil && !m.health.pending
+}
+
+// fixFinding runs the highlighted finding's fix in the background and checks the dashboard again afterwards
+func (m Model) fixFinding() (tea.Model, tea.Cmd) {
+	finding, ok := m.currentFinding()
+	if !ok || finding.Fix == nil {
+		return m, nil
+	}
+	fix := finding.Fix
+	project := finding.Project
+
+	m.statusMsg = fmt.Sprintf("Running %s in %s...", fix.Label, project.Name)
+	m.startJob(fmt.Sprintf("Fix %s: %s", project.Name, fix.Label), func(ctx context.Context, report jobs.Reporter) (tea.Msg, error) {
+		output, err := fix.Run(ctx, project.Path)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%s: %s", fix.Label, failureSummary(err.Error(), output))
+		}
+		return healthFixedMsg{project: project, fix: fix.Label}, nil
+	})
+	return m, nil
+}
+
+// handleHealthFixed re-checks the fixed project and replaces its findings
+func (m Model) handleHealthFixed(msg healthFixedMsg) (tea.Model, tea.Cmd) {
+	m.statusMsg = fmt.Sprintf("Ran %s in %s", msg.fix, msg.project.Name)
+	project := msg.project
+	if updated, ok := scanner.ScanProject(project.Path); ok {
+		project = updated
+	}
+	m.health.findings = slices.DeleteFunc(m.health.findings, func(f health.Finding) bool {
+		return f.Project.Path == project.Path
+	})
+	m.health.findings = append(m.health.findings, health.CheckProject(project, time.Now())...)
+	sortFindings(m.health.findings, m.health.sortBy)
+	m.health.cursor = min(m.health.cursor, max(len(m.health.findings)-1, 0))
+	return m, nil
+}
+
+// updateHealth handles updates in the health dashboard state
+func (m Model) updateHealth(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keyMap.Up):
+			if m.health.cursor > 0 {
+				m.health.cursor--
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Down):
+			if m.health.cursor < len(m.health.findings)-1 {
+				m.health.cursor++
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Sort):
+			m.health.sortBy = (m.health.sortBy + 1) % healthSortCount
+			sortFindings(m.health.findings, m.health.sortBy)
+			m.health.cursor = 0
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Fix):
+			if !m.canFixFinding() {
+				return m, nil
+			}
+			return m.fixFinding()
+
+		case key.Matches(msg, m.keyMap.Scan):
+			if m.health.pending {
+				return m, nil
+			}
+			return m.openHealth()
+
+		case key.Matches(msg, m.keyMap.Back):
+			m.state = m.health.back
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
+
+// listWindow returns the slice of a long list to show so the cursor stays in view, centred where possible
+func listWindow(cursor, total, size int) (start, end int) {
+	start = min(max(cursor-size/2, 0), max(total-size, 0))
+	return start, min(start+size, total)
+}
+
+// severityIcon returns a one-character marker for a finding's severity
+func severityIcon(s health.Severity) string {
+	switch s {
+	case health.Error:
+		return "✗"
+	case health.Warning:
+		return "!"
+	default:
+		return "·"
+	}
+}
+
+// severityLabel renders a finding's severity as a coloured label
+func severityLabel(s health.Severity) string {
+	label := strings.ToUpper(s.String()) + ":"
+	switch s {
+	case health.Error:
+		return ErrorStyle.Render(label)
+	case health.Warning:
+		return WarningStyle.Render(label)
+	default:
+		return StatusStyle.Render(label)
+	}
+}
+
+// viewHealth renders the health dashboard
+func (m Model) viewHealth() string {
+	var b strings.Builder
+
+	title := TitleStyle.Render("Project Health")
+	b.WriteString(title + "\n")
+
+	divider := lipgloss.NewStyle().
+		Foreground(secondaryColor).
+		Render("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	b.WriteString(divider + "\n\n")
+
+	if m.health.pending {
+		b.WriteString(FancyBoxStyle.Render(fmt.Sprintf("%s Checking %d projects...", m.spinner.View(), len(m.projects))) + "\n\n")
+	} else if len(m.health.findings) == 0 {
+		b.WriteString(FancyBoxStyle.Render(SuccessStyle.Render(fmt.Sprintf("✓ All %d projects look healthy.", m.health.checked))) + "\n\n")
+	} else {
+		counts := make(map[health.Severity]int)
+		for _, finding := range m.health.findings {
+			counts[finding.Severity]++
+		}
+		summary := fmt.Sprintf("%s • %s • %s across %d projects • sorted by %s",
+			ErrorStyle.Render(fmt.Sprintf("%d errors", counts[health.Error])),
+			WarningStyle.Render(fmt.Sprintf("%d warnings", counts[health.Warning])),
+			StatusStyle.Render(fmt.Sprintf("%d info", counts[health.Info])),
+			m.health.checked, HighlightStyle.Render(m.health.sortBy.String()))
+		b.WriteString(summary + "\n\n")
+
+		start, end := listWindow(m.health.cursor, len(m.health.findings), healthRows)
+		var rows []string
+		for i := start; i < end; i++ {
+			finding := m.health.findings[i]
+			row := fmt.Sprintf("%s %-24s %s", severityIcon(finding.Severity), truncate(finding.Project.Name, 24), finding.Check)
+			if i == m.health.cursor {
+				rows = append(rows, SelectedProjectStyle.Render(" > "+row))
+			} else {
+				rows = append(rows, ProjectStyle.Render("   "+row))
+			}
+		}
+		if len(m.health.findings) > healthRows {
+			rows = append(rows, StatusStyle.Render(fmt.Sprintf("   %d-%d of %d findings", start+1, end, len(m.health.findings))))
+		}
+		b.WriteString(ProjectListStyle.Render(strings.Join(rows, "\n")) + "\n\n")
+
+		if finding, ok := m.currentFinding(); ok {
+			detail := fmt.Sprintf("%s %s", severityLabel(finding.Severity), finding.Message)
+			if finding.Fix != nil {
+				detail += "\n" + StatusStyle.Render(fmt.Sprintf("Fix: %s (%s)", finding.Fix.Label, m.keyMap.Fix.Help().Key))
+			}
+			b.WriteString(detail + "\n\n")
+		}
+	}
+
+	if m.statusMsg != "" {
+		b.WriteString(StatusStyle.Render(m.statusMsg) + "\n\n")
+	}
+	if m.error != "" {
+		b.WriteString(ErrorStyle.Render("Error: "+m.error) + "\n\n")
+	}
+
+	help := m.helpView()
+	b.WriteString(help)
+
+	return BaseStyle.Render(b.String())
+}
@@ -0,0 +1,254 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/chloebubble/tuv/pkg/jobs"
+)
+
+// keepFinishedJobs is how many finished jobs stay listed in the jobs panel
+const keepFinishedJobs = 50
+
+// jobFunc is background work that produces a message for Update once it finishes
+type jobFunc func(ctx context.Context, report jobs.Reporter) (tea.Msg, error)
+
+// startJob queues work on the job manager. Its result message is delivered to Update when it completes.
+func (m Model) startJob(name string, fn jobFunc) int {
+	return m.jobs.Submit(name, func(ctx context.Context, report jobs.Reporter) (any, error) {
+		return fn(ctx, report)
+	})
+}
+
+// waitForJobEvent waits for the next job state change
+func waitForJobEvent(manager *jobs.Manager) tea.Cmd {
+	return func() tea.Msg {
+		return jobEventMsg{<-manager.Events()}
+	}
+}
+
+// handleJobEvent delivers finished jobs' results and raises a notification for them
+func (m Model) handleJobEvent(msg jobEventMsg) (tea.Model, tea.Cmd) {
+	listen := waitForJobEvent(m.jobs)
+	job := msg.job
+	if !job.Status.Done() {
+		return m, listen
+	}
+	m.jobs.Prune(keepFinishedJobs)
+
+	switch job.Status {
+	case jobs.Succeeded:
+		m.notice = SuccessStyle.Render("✓ ") + job.Name
+	case jobs.Failed:
+		m.notice = ErrorStyle.Render("✗ ") + job.Name
+	case jobs.Cancelled:
+		m.notice = WarningStyle.Render("⊘ ") + job.Name + " cancelled"
+	}
+
+	var result tea.Msg
+	if job.Result != nil {
+		result = job.Result
+	} else if job.Status == jobs.Failed {
+		result = errMsg{fmt.Errorf("%s: %w", job.Name, job.Err)}
+	}
+	m.jobEnded(job)
+	if result == nil {
+		return m, listen
+	}
+
+	updated, cmd := m.Update(result)
+	return updated, tea.Batch(listen, cmd)
+}
+
+// awaitJob records that a screen is waiting on a job's result. A screen waits on one job at a
+// time, so starting another replaces it.
+func (m Model) awaitJob(screen AppState, id int) {
+	m.waiting[screen] = id
+}
+
+// jobEnded forgets the screens that were waiting on a finished job. If it ended without a result,
+// those screens, and only those, stop waiting for one.
+func (m *Model) jobEnded(job jobs.Job) {
+	for screen, id := range m.waiting {
+		if id != job.ID {
+			continue
+		}
+		delete(m.waiting, screen)
+		if job.Result != nil {
+			continue
+		}
+		switch screen {
+		case StateLoading:
+			m.loading = false
+		case StateClean:
+			m.clean.pending = false
+		case StateStale:
+			m.stalePending = false
+		case StateArchives:
+			m.archivesPending = false
+		case StateDiskUsage:
+			m.usage.pending = false
+		case StateTests:
+			m.tests.pending = false
+		case StateMigrate:
+			m.migrate.pending = false
+		case StateHealth:
+			m.health.pending = false
+		case StateAudit:
+			m.audit.pending = false
+		case StateLicenses:
+			m.licenses.pending = false
+		case StateExport:
+			m.export.pending = false
+		}
+	}
+}
+
+// openJobs shows the jobs panel, returning to the current screen afterwards
+func (m Model) openJobs() (tea.Model, tea.Cmd) {
+	if m.state != StateJobs {
+		m.jobsBack = m.state
+	}
+	m.state = StateJobs
+	m.jobCursor = 0
+	return m, nil
+}
+
+// updateJobs handles updates in the jobs panel state
+func (m Model) updateJobs(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		list := m.jobs.Jobs()
+
+		switch {
+		case key.Matches(msg, m.keyMap.Up):
+			if m.jobCursor > 0 {
+				m.jobCursor--
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Down):
+			if m.jobCursor < len(list)-1 {
+				m.jobCursor++
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Stop):
+			if m.jobCursor < len(list) {
+				job := list[m.jobCursor]
+				if m.jobs.Cancel(job.ID) {
+					m.statusMsg = fmt.Sprintf("Cancelling %s...", job.Name)
+				}
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Back), key.Matches(msg, m.keyMap.Jobs):
+			m.state = m.jobsBack
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
+
+// viewJobs renders the jobs panel
+func (m Model) viewJobs() string {
+	var b strings.Builder
+
+	title := TitleStyle.Render("Jobs")
+	b.WriteString(title + "\n")
+
+	divider := lipgloss.NewStyle().
+		Foreground(secondaryColor).
+		Render("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	b.WriteString(divider + "\n\n")
+
+	list := m.jobs.Jobs()
+	if len(list) == 0 {
+		b.WriteString(FancyBoxStyle.Render("No jobs have run yet.") + "\n\n")
+	} else {
+		b.WriteString(fmt.Sprintf("%s active • up to %s at once",
+			HighlightStyle.Render(fmt.Sprintf("%d", m.jobs.Active())),
+			HighlightStyle.Render(fmt.Sprintf("%d", m.config.MaxJobs))) + "\n\n")
+
+		var rows []string
+		for i, job := range list {
+			row := fmt.Sprintf("%s %-28s %-16s %6s", jobIcon(job.Status), truncate(job.Name, 28), jobProgress(job), job.Elapsed().Round(time.Second))
+			if i == m.jobCursor {
+				rows = append(rows, SelectedProjectStyle.Render(" > "+row))
+			} else {
+				rows = append(rows, ProjectStyle.Render("   "+row))
+			}
+		}
+		b.WriteString(ProjectListStyle.Render(strings.Join(rows, "\n")) + "\n\n")
+
+		if m.jobCursor < len(list) {
+			job := list[m.jobCursor]
+			detail := job.Status.String()
+			if job.Message != "" {
+				detail += " • " + job.Message
+			}
+			if job.Err != nil {
+				detail += "\n" + ErrorStyle.Render(job.Err.Error())
+			}
+			b.WriteString(StatusStyle.Render(detail) + "\n\n")
+		}
+	}
+
+	if m.statusMsg != "" {
+		b.WriteString(StatusStyle.Render(m.statusMsg) + "\n\n")
+	}
+
+	help := m.helpView()
+	b.WriteString(help)
+
+	return BaseStyle.Render(b.String())
+}
+
+// jobIcon returns a one-character marker for a job's status
+func jobIcon(status jobs.Status) string {
+	switch status {
+	case jobs.Queued:
+		return "…"
+	case jobs.Running:
+		return "▶"
+	case jobs.Succeeded:
+		return "✓"
+	case jobs.Failed:
+		return "✗"
+	default:
+		return "⊘"
+	}
+}
+
+// jobProgress renders a job's progress as a small bar, or its status if progress is unknown
+func jobProgress(job jobs.Job) string {
+	if job.Status != jobs.Running || job.Progress < 0 {
+		return job.Status.String()
+	}
+	const width = 10
+	filled := min(int(job.Progress*width), width)
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled) + fmt.Sprintf(" %3d%%", int(job.Progress*100))
+}
+
+// viewJobStatus renders the footer line with active job count and the latest notification
+func (m Model) viewJobStatus() string {
+	var parts []string
+	if active := m.jobs.Active(); active > 0 {
+		parts = append(parts, fmt.Sprintf("%s %d job(s) running", m.spinner.View(), active))
+	}
+	if m.notice != "" {
+		parts = append(parts, m.notice)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	jobs := m.keyMap.Jobs.Help()
+	parts = append(parts, jobs.Key+": "+jobs.Desc)
+	return StatusStyle.Render(strings.Join(parts, " • "))
+}
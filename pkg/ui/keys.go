@@ -0,0 +1,511 @@
+package ui
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// KeyMap defines the keybindings for the application
+type KeyMap struct {
+	Up         key.Binding
+	Down       key.Binding
+	Select     key.Binding
+	Back       key.Binding
+	Quit       key.Binding
+	Scan       key.Binding
+	Toggle     key.Binding
+	SelectAll  key.Binding
+	Invert     key.Binding
+	Filter     key.Binding
+	Actions    key.Binding
+	Confirm    key.Binding
+	Cancel     key.Binding
+	Clean      key.Binding
+	DeleteVenv key.Binding
+	Dismiss    key.Binding
+	Archive    key.Binding
+	Usage      key.Binding
+	NextTab    key.Binding
+	DirtyOnly  key.Binding
+	Edit       key.Binding
+	Shell      key.Binding
+	Open       key.Binding
+	Stop       key.Binding
+	Jobs       key.Binding
+	Test       key.Binding
+	Migrate    key.Binding
+	Expand     key.Binding
+	Collapse   key.Binding
+	Sort       key.Binding
+	Fix        key.Binding
+	Export     key.Binding
+}
+
+// DefaultKeyMap returns the default keybindings
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "down"),
+		),
+		Select: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "select"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "back"),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("ctrl+c", "q"),
+			key.WithHelp("ctrl+c/q", "quit"),
+		),
+		Scan: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "scan"),
+		),
+		Toggle: key.NewBinding(
+			key.WithKeys(" "),
+			key.WithHelp("space", "toggle"),
+		),
+		SelectAll: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "select all"),
+		),
+		Invert: key.NewBinding(
+			key.WithKeys("i"),
+			key.WithHelp("i", "invert"),
+		),
+		Filter: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "select by filter"),
+		),
+		Actions: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("b", "bulk actions"),
+		),
+		Confirm: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "confirm"),
+		),
+		Cancel: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "cancel"),
+		),
+		Clean: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "clean"),
+		),
+		DeleteVenv: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "delete venv"),
+		),
+		Dismiss: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "dismiss"),
+		),
+		Archive: key.NewBinding(
+			key.WithKeys("z"),
+			key.WithHelp("z", "archive"),
+		),
+		Usage: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "disk usage"),
+		),
+		NextTab: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "next tab"),
+		),
+		DirtyOnly: key.NewBinding(
+			key.WithKeys("g"),
+			key.WithHelp("g", "dirty only"),
+		),
+		Edit: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "editor"),
+		),
+		Shell: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "shell"),
+		),
+		Open: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "file manager"),
+		),
+		Stop: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "stop"),
+		),
+		Jobs: key.NewBinding(
+			key.WithKeys("J"),
+			key.WithHelp("J", "jobs"),
+		),
+		Test: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "test"),
+		),
+		Migrate: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "migrate to uv"),
+		),
+		Expand: key.NewBinding(
+			key.WithKeys("right", "l"),
+			key.WithHelp("→/l", "expand workspace"),
+		),
+		Collapse: key.NewBinding(
+			key.WithKeys("left", "h"),
+			key.WithHelp("←/h", "collapse workspace"),
+		),
+		Sort: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "sort"),
+		),
+		Fix: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "fix"),
+		),
+		Export: key.NewBinding(
+			key.WithKeys("E"),
+			key.WithHelp("E", "export"),
+		),
+	}
+}
+
+// named maps the action names used in the keys section of the config to their bindings
+func (k *KeyMap) named() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"up":          &k.Up,
+		"down":        &k.Down,
+		"select":      &k.Select,
+		"back":        &k.Back,
+		"quit":        &k.Quit,
+		"scan":        &k.Scan,
+		"toggle":      &k.Toggle,
+		"select_all":  &k.SelectAll,
+		"invert":      &k.Invert,
+		"filter":      &k.Filter,
+		"actions":     &k.Actions,
+		"confirm":     &k.Confirm,
+		"cancel":      &k.Cancel,
+		"clean":       &k.Clean,
+		"delete_venv": &k.DeleteVenv,
+		"dismiss":     &k.Dismiss,
+		"archive":     &k.Archive,
+		"usage":       &k.Usage,
+		"next_tab":    &k.NextTab,
+		"dirty_only":  &k.DirtyOnly,
+		"edit":        &k.Edit,
+		"shell":       &k.Shell,
+		"open":        &k.Open,
+		"stop":        &k.Stop,
+		"jobs":        &k.Jobs,
+		"test":        &k.Test,
+		"migrate":     &k.Migrate,
+		"expand":      &k.Expand,
+		"collapse":    &k.Collapse,
+		"sort":        &k.Sort,
+		"fix":         &k.Fix,
+		"export":      &k.Export,
+	}
+}
+
+// NewKeyMap applies keybinding overrides from the config on top of the defaults.
+// It fails if an action is unknown or if two actions on the same screen share a key.
+func NewKeyMap(overrides map[string][]string) (KeyMap, error) {
+	km := DefaultKeyMap()
+	named := km.named()
+
+	for name, keys := range overrides {
+		binding, ok := named[name]
+		if !ok {
+			return km, fmt.Errorf("unknown action %q (valid actions: %s)", name, strings.Join(slices.Sorted(maps.Keys(named)), ", "))
+		}
+		if len(keys) == 0 {
+			return km, fmt.Errorf("action %q has no keys", name)
+		}
+		binding.SetKeys(keys...)
+		binding.SetHelp(keyLabel(keys), binding.Help().Desc)
+	}
+
+	if err := km.validate(); err != nil {
+		return km, err
+	}
+	return km, nil
+}
+
+// screenActions lists the actions each screen's update handler matches, beyond quit and jobs,
+// which every screen handles. Actions grouped together do the same thing on that screen, so they
+// may share a key.
+var screenActions = map[AppState][][]string{
+	StateMainMenu:     {{"up"}, {"down"}, {"select"}, {"scan"}},
+	StateFirstRun:     {{"select"}},
+	StateNewProject:   {{"select"}, {"back"}},
+	StateSelectFilter: {{"select"}, {"back"}},
+	StateProjectList: {
+		{"up"}, {"down"}, {"select"}, {"back"}, {"scan"}, {"toggle"}, {"select_all"}, {"invert"}, {"expand"},
+		{"collapse"}, {"filter"}, {"actions"}, {"clean"}, {"dirty_only"}, {"edit"}, {"shell"}, {"open"},
+		{"test"}, {"migrate"}, {"fix"},
+	},
+	StateProjectDetail: {
+		{"back"}, {"next_tab"}, {"up"}, {"down"}, {"select"}, {"clean"}, {"usage"}, {"edit"}, {"shell"},
+		{"open"}, {"test"}, {"migrate"}, {"fix"},
+	},
+	StateBulkMenu:     {{"up"}, {"down"}, {"select"}, {"back"}},
+	StateBulkConfirm:  {{"confirm"}, {"cancel", "back"}},
+	StateBulkReport:   {{"back", "select"}},
+	StateClean:        {{"up"}, {"down"}, {"toggle"}, {"select"}, {"back"}},
+	StateStale:        {{"confirm"}, {"cancel", "back"}, {"up"}, {"down"}, {"delete_venv"}, {"archive"}, {"dismiss"}},
+	StateArchives:     {{"up"}, {"down"}, {"select"}, {"back"}},
+	StateDiskUsage:    {{"up"}, {"down"}, {"next_tab"}, {"select"}, {"back"}},
+	StateRun:          {{"stop"}, {"back"}},
+	StateJobs:         {{"up"}, {"down"}, {"stop"}, {"back", "jobs"}},
+	StateTests:        {{"up"}, {"down"}, {"select", "toggle"}, {"test"}, {"back"}},
+	StateThemes:       {{"up"}, {"down"}, {"select"}, {"back"}},
+	StateSettings:     {{"up"}, {"down"}, {"select", "toggle"}, {"back"}},
+	StateSettingsEdit: {{"select"}, {"back"}},
+	StateMigrate:      {{"select"}, {"up"}, {"down"}, {"back"}},
+	StateHealth:       {{"up"}, {"down"}, {"sort"}, {"fix"}, {"scan"}, {"back"}},
+	StateAudit:        {{"up"}, {"down"}, {"select"}, {"scan"}, {"back"}},
+	StateLicenses:     {{"up"}, {"down"}, {"select"}, {"export"}, {"scan"}, {"back"}},
+	StateExport:       {{"confirm"}, {"cancel", "back"}, {"up"}, {"down"}, {"toggle"}, {"select"}},
+}
+
+// validate checks that no screen has two actions bound to the same key
+func (k KeyMap) validate() error {
+	named := k.named()
+	for state := StateMainMenu; state < stateCount; state++ {
+		groups := screenActions[state]
+		actions := []string{"quit"}
+		if !inTextInput(state) {
+			actions = append(actions, "jobs")
+		}
+		for _, group := range groups {
+			actions = append(actions, group...)
+		}
+
+		used := make(map[string]string)
+		for _, action := range actions {
+			binding := *named[action]
+			if action == "quit" && inTextInput(state) {
+				binding = withoutRunes(binding)
+			}
+			for _, keyName := range binding.Keys() {
+				if other, ok := used[keyName]; ok && other != action && !sameAction(groups, other, action) {
+					return fmt.Errorf("%q is bound to both %s and %s", keyName, other, action)
+				}
+				used[keyName] = action
+			}
+		}
+	}
+	return nil
+}
+
+// sameAction reports whether two actions are in the same group, doing the same thing on a screen
+func sameAction(groups [][]string, a, b string) bool {
+	return slices.ContainsFunc(groups, func(group []string) bool {
+		return slices.Contains(group, a) && slices.Contains(group, b)
+	})
+}
+
+// keyLabel renders keys the way the help line shows them, e.g. "↑/k"
+func keyLabel(keys []string) string {
+	labels := make([]string, len(keys))
+	for i, k := range keys {
+		switch k {
+		case "up":
+			labels[i] = "↑"
+		case "down":
+			labels[i] = "↓"
+		case "left":
+			labels[i] = "←"
+		case "right":
+			labels[i] = "→"
+		case " ":
+			labels[i] = "space"
+		default:
+			labels[i] = k
+		}
+	}
+	return strings.Join(labels, "/")
+}
+
+// as returns a copy of a binding described differently for one screen
+func as(b key.Binding, desc string) key.Binding {
+	b.SetHelp(b.Help().Key, desc)
+	return b
+}
+
+// when returns a copy of a binding that is only shown while enabled is true
+func when(b key.Binding, enabled bool) key.Binding {
+	b.SetEnabled(enabled)
+	return b
+}
+
+// either combines bindings that do the same thing on a screen into one help entry
+func either(desc string, bindings ...key.Binding) key.Binding {
+	var keys, labels []string
+	for _, b := range bindings {
+		keys = append(keys, b.Keys()...)
+		labels = append(labels, b.Help().Key)
+	}
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp(strings.Join(labels, "/"), desc))
+}
+
+// withoutRunes returns a copy of a binding without its printable keys, for screens that are typing into a text field
+func withoutRunes(b key.Binding) key.Binding {
+	var keys []string
+	for _, k := range b.Keys() {
+		if len([]rune(k)) > 1 {
+			keys = append(keys, k)
+		}
+	}
+	b.SetKeys(keys...)
+	b.SetHelp(keyLabel(keys), b.Help().Desc)
+	return b
+}
+
+// keysFor returns the bindings active on a screen, described for that screen. Bindings that
+// only apply in some situations are disabled rather than left out, so validation still sees them.
+func (m Model) keysFor(state AppState) []key.Binding {
+	k := m.keyMap
+	quit := k.Quit
+	if inTextInput(state) {
+		quit = withoutRunes(quit)
+	}
+	global := []key.Binding{k.Jobs, quit}
+
+	switch state {
+	case StateMainMenu:
+		return append([]key.Binding{k.Up, k.Down, k.Select, as(k.Scan, "rescan")}, global...)
+	case StateFirstRun:
+		return []key.Binding{as(k.Select, "save"), quit}
+	case StateNewProject:
+		return []key.Binding{as(k.Select, "create"), k.Back, quit}
+	case StateSelectFilter:
+		return []key.Binding{as(k.Select, "select matches"), k.Back, quit}
+	case StateProjectList:
+		return append([]key.Binding{
+			k.Up, k.Down, as(k.Select, "details"), k.Toggle, k.SelectAll, k.Invert, k.Filter, k.Actions,
+			k.Clean, k.DirtyOnly, k.Edit, k.Shell, k.Open, k.Test, when(k.Migrate, m.canMigrate()),
+			when(k.Expand, m.canExpand()), when(k.Collapse, m.canCollapse()), when(as(k.Fix, "fix drift"), m.canResolveDrift()),
+			as(k.Scan, "rescan"), k.Back,
+		}, global...)
+	case StateProjectDetail:
+		scripts := detailTabs[m.detailTab] == "Scripts"
+		return append([]key.Binding{
+			k.NextTab, when(k.Up, scripts), when(k.Down, scripts), when(as(k.Select, "run script"), scripts),
+			k.Clean, k.Usage, k.Edit, k.Shell, k.Open, k.Test, when(k.Migrate, m.canMigrate()),
+			when(as(k.Fix, "fix drift"), m.canResolveDrift()), k.Back,
+		}, global...)
+	case StateBulkMenu:
+		return append([]key.Binding{k.Up, k.Down, as(k.Select, "run"), k.Back}, global...)
+	case StateBulkConfirm:
+		return append([]key.Binding{k.Confirm, either("cancel", k.Cancel, k.Back)}, global...)
+	case StateBulkReport:
+		back := "back to projects"
+		if m.bulkRunning() {
+			back = "back (keeps running)"
+		}
+		return append([]key.Binding{either(back, k.Select, k.Back)}, global...)
+	case StateClean:
+		choosing := !m.clean.done && !m.clean.pending
+		selectDesc := "clean"
+		if m.clean.done {
+			selectDesc = "back"
+		}
+		return append([]key.Binding{
+			when(k.Up, choosing), when(k.Down, choosing), when(k.Toggle, choosing),
+			when(as(k.Select, selectDesc), !m.clean.pending), k.Back,
+		}, global...)
+	case StateExport:
+		if m.export.replacing != nil {
+			return append([]key.Binding{as(k.Confirm, "replace"), either("cancel", k.Cancel, k.Back)}, global...)
+		}
+		choosing := !m.export.done && !m.export.pending
+		selectDesc := "export"
+		if m.export.done {
+			selectDesc = "back"
+		}
+		return append([]key.Binding{
+			when(k.Up, choosing), when(k.Down, choosing), when(k.Toggle, choosing),
+			when(as(k.Select, selectDesc), !m.export.pending), k.Back,
+		}, global...)
+	case StateStale:
+		if m.staleConfirm != "" {
+			return append([]key.Binding{k.Confirm, either("cancel", k.Cancel, k.Back)}, global...)
+		}
+		return append([]key.Binding{k.Up, k.Down, k.DeleteVenv, k.Archive, k.Dismiss, k.Back}, global...)
+	case StateArchives:
+		return append([]key.Binding{k.Up, k.Down, as(k.Select, "restore"), k.Back}, global...)
+	case StateDiskUsage:
+		return append([]key.Binding{
+			k.Up, k.Down, when(as(k.Select, "open directory"), !m.usage.packagesTab),
+			as(k.NextTab, "directories/packages"), as(k.Back, "up/back"),
+		}, global...)
+	case StateRun:
+		running := m.run.result == nil
+		return append([]key.Binding{when(k.Stop, running && !m.run.killed), when(k.Back, !running || m.run.killed)}, global...)
+	case StateJobs:
+		return []key.Binding{k.Up, k.Down, as(k.Stop, "cancel job"), either("back", k.Back, k.Jobs), quit}
+	case StateTests:
+		return append([]key.Binding{
+			k.Up, k.Down, either("traceback", k.Select, k.Toggle), as(k.Test, "run again"), k.Back,
+		}, global...)
+	case StateThemes:
+		return append([]key.Binding{k.Up, k.Down, as(k.Select, "apply"), as(k.Back, "cancel")}, global...)
+	case StateSettings:
+		toggles := settings[m.settings.cursor].kind == settingBool
+		return append([]key.Binding{k.Up, k.Down, as(k.Select, "edit"), when(k.Toggle, toggles), k.Back}, global...)
+	case StateSettingsEdit:
+		return []key.Binding{as(k.Select, "save"), as(k.Back, "cancel"), quit}
+	case StateHealth:
+		return append([]key.Binding{
+			k.Up, k.Down, k.Sort, when(k.Fix, m.canFixFinding()), when(as(k.Scan, "check again"), !m.health.pending), k.Back,
+		}, global...)
+	case StateAudit:
+		return append([]key.Binding{
+			k.Up, k.Down, when(as(k.Select, "findings"), m.canOpenReport()),
+			when(as(k.Scan, "audit again"), !m.audit.pending), k.Back,
+		}, global...)
+	case StateLicenses:
+		return append([]key.Binding{
+			k.Up, k.Down, when(as(k.Select, "packages"), m.canOpenLicenses()), when(k.Export, m.canExportLicenses()),
+			when(as(k.Scan, "check again"), !m.licenses.pending), k.Back,
+		}, global...)
+	case StateMigrate:
+		selectDesc := "migrate"
+		if m.migrate.done {
+			selectDesc = "back"
+		}
+		scrolls := m.migrate.canRunMigrate() && len(m.migrate.diff) > diffHeight
+		return append([]key.Binding{
+			when(k.Up, scrolls), when(k.Down, scrolls), when(as(k.Select, selectDesc), m.migrate.done || m.migrate.canRunMigrate()), k.Back,
+		}, global...)
+	default:
+		return []key.Binding{quit}
+	}
+}
+
+// newHelp returns a help component styled to match the rest of the UI
+func newHelp() help.Model {
+	h := help.New()
+	h.Styles.ShortKey = HelpStyle.Bold(true)
+	h.Styles.ShortDesc = HelpStyle
+	h.Styles.ShortSeparator = HelpStyle
+	h.Styles.Ellipsis = HelpStyle
+	return h
+}
+
+// helpView renders the help line for the current screen from the active keybindings
+func (m Model) helpView() string {
+	return m.help.ShortHelpView(m.keysFor(m.state))
+}
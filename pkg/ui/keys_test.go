@@ -0,0 +1,45 @@
+package ui
+
+import "testing"
+
+func TestNewKeyMap(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string][]string
+		wantErr   bool
+	}{
+		{name: "defaults"},
+		{name: "free key", overrides: map[string][]string{"dismiss": {"X"}}},
+		{name: "cancel shares back's key", overrides: map[string][]string{"cancel": {"esc"}}},
+		{name: "toggle on the select key", overrides: map[string][]string{"toggle": {"enter"}}, wantErr: true},
+		{name: "back on a quit key", overrides: map[string][]string{"back": {"q"}}, wantErr: true},
+		{name: "stop on the back key", overrides: map[string][]string{"stop": {"esc"}}, wantErr: true},
+		{name: "jobs on the back key", overrides: map[string][]string{"jobs": {"esc"}}, wantErr: true},
+		{name: "sort on the scan key", overrides: map[string][]string{"sort": {"s"}}, wantErr: true},
+		{name: "fix on the edit key", overrides: map[string][]string{"fix": {"e"}}, wantErr: true},
+		{name: "unknown action", overrides: map[string][]string{"fly": {"F"}}, wantErr: true},
+		{name: "no keys", overrides: map[string][]string{"scan": {}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewKeyMap(tt.overrides)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewKeyMap(%v) error = %v, want error %v", tt.overrides, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestScreenActionsAreNamed(t *testing.T) {
+	km := DefaultKeyMap()
+	named := km.named()
+	for state, groups := range screenActions {
+		for _, group := range groups {
+			for _, action := range group {
+				if _, ok := named[action]; !ok {
+					t.Errorf("screen %d lists unknown action %q", state, action)
+				}
+			}
+		}
+	}
+}
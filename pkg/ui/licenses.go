@@ -0,0 +1,338 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/chloebubble/tuv/pkg/config"
+	"github.com/chloebubble/tuv/pkg/jobs"
+	"github.com/chloebubble/tuv/pkg/licenses"
+	"github.com/chloebubble/tuv/pkg/scanner"
+)
+
+// licenseRows is how many projects or packages the licenses screen lists at once
+const licenseRows = 10
+
+// licensesState holds the license reports and which of them is open. Row 0 of the project
+// list is every project combined, the rest are the reports in order.
+type licensesState struct {
+	reports []licenses.Report
+	usages  []licenses.Usage
+	pending bool
+	cursor  int
+	// open is whether the highlighted row's packages are shown instead of the project list
+	open        bool
+	entryCursor int
+	back        AppState
+}
+
+// openLicenses reads the licenses installed in every project's venv in the background
+func (m Model) openLicenses() (tea.Model, tea.Cmd) {
+	back := m.state
+	if back == StateLicenses {
+		back = m.licenses.back
+	}
+	m.licenses = licensesState{pending: true, back: back}
+	m.state = StateLicenses
+	m.error = ""
+
+	projects := m.projects
+	deny := m.config.LicenseDeny
+	id := m.startJob("Check licenses", func(ctx context.Context, report jobs.Reporter) (tea.Msg, error) {
+		reports, err := licenses.Run(ctx, projects, deny, func(done int, project scanner.UVProject) {
+			report(float64(done)/float64(len(projects)), project.Name)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return licensesCheckedMsg{reports: reports}, nil
+	})
+	m.awaitJob(StateLicenses, id)
+	return m, nil
+}
+
+// handleLicensesChecked shows the finished license reports
+func (m Model) handleLicensesChecked(msg licensesCheckedMsg) (tea.Model, tea.Cmd) {
+	m.licenses.reports = msg.reports
+	m.licenses.usages = licenses.Combine(msg.reports)
+	m.licenses.pending = false
+	m.licenses.cursor = 0
+	m.licenses.open = false
+	return m, nil
+}
+
+// licenseEntries returns the packages of the highlighted row, with the projects using each
+// when the row is every project combined
+func (m Model) licenseEntries() []licenses.Usage {
+	if m.licenses.cursor == 0 {
+		return m.licenses.usages
+	}
+	if m.licenses.cursor > len(m.licenses.reports) {
+		return nil
+	}
+	report := m.licenses.reports[m.licenses.cursor-1]
+	usages := make([]licenses.Usage, len(report.Entries))
+	for i, entry := range report.Entries {
+		usages[i] = licenses.Usage{Entry: entry}
+	}
+	return usages
+}
+
+// canOpenLicenses reports whether the highlighted row has packages to show
+func (m Model) canOpenLicenses() bool {
+	return !m.licenses.open && len(m.licenseEntries()) > 0
+}
+
+// canExportLicenses reports whether there are license reports to export
+func (m Model) canExportLicenses() bool {
+	return !m.licenses.pending && len(m.licenses.reports) > 0
+}
+
+// exportLicenses writes every report to a CSV file in the data directory
+func (m Model) exportLicenses() (tea.Model, tea.Cmd) {
+	dir := filepath.Join(config.DataDir(), "reports")
+	path := filepath.Join(dir, "licenses-"+time.Now().Format("20060102-150405")+".csv")
+	err := os.MkdirAll(dir, 0755)
+	if err == nil {
+		var f *os.File
+		if f, err = os.Create(path); err == nil {
+			err = licenses.WriteCSV(f, m.licenses.reports)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+		}
+	}
+	if err != nil {
+		m.error = fmt.Sprintf("Failed to export licenses: %v", err)
+		return m, nil
+	}
+	m.error = ""
+	m.statusMsg = "Exported license report to " + path
+	return m, nil
+}
+
+// updateLicenses handles updates in the licenses state
+func (m Model) updateLicenses(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keyMap.Up):
+			if m.licenses.open && m.licenses.entryCursor > 0 {
+				m.licenses.entryCursor--
+			} else if !m.licenses.open && m.licenses.cursor > 0 {
+				m.licenses.cursor--
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Down):
+			if m.licenses.open {
+				if m.licenses.entryCursor < len(m.licenseEntries())-1 {
+					m.licenses.entryCursor++
+				}
+			} else if m.licenses.cursor < len(m.licenses.reports) {
+				m.licenses.cursor++
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Select):
+			if m.canOpenLicenses() {
+				m.licenses.open = true
+				m.licenses.entryCursor = 0
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Export):
+			if m.canExportLicenses() {
+				return m.exportLicenses()
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Scan):
+			if m.licenses.pending {
+				return m, nil
+			}
+			return m.openLicenses()
+
+		case key.Matches(msg, m.keyMap.Back):
+			if m.licenses.open {
+				m.licenses.open = false
+				return m, nil
+			}
+			m.state = m.licenses.back
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
+
+// licenseStyle returns the style packages with a license status are shown in
+func licenseStyle(s licenses.Status) lipgloss.Style {
+	switch s {
+	case licenses.Denied:
+		return ErrorStyle
+	case licenses.Unknown:
+		return WarningStyle
+	default:
+		return StatusStyle
+	}
+}
+
+// licenseName returns the classified license, or the first line of the declared one if it couldn't be classified
+func licenseName(e licenses.Entry) string {
+	if e.License != "" {
+		return e.License
+	}
+	declared, _, _ := strings.Cut(strings.TrimSpace(e.Declared), "\n")
+	if declared == "" {
+		return "not declared"
+	}
+	return declared
+}
+
+// viewLicenses renders the project list or the open row's packages
+func (m Model) viewLicenses() string {
+	var b strings.Builder
+
+	title := "Licenses"
+	if m.licenses.open {
+		if m.licenses.cursor == 0 {
+			title += ": all projects"
+		} else {
+			title += ": " + m.licenses.reports[m.licenses.cursor-1].Project.Name
+		}
+	}
+	b.WriteString(TitleStyle.Render(title) + "\n")
+
+	divider := lipgloss.NewStyle().
+		Foreground(secondaryColor).
+		Render("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	b.WriteString(divider + "\n\n")
+
+	switch {
+	case m.licenses.pending:
+		b.WriteString(FancyBoxStyle.Render(fmt.Sprintf("%s Reading licenses of %d projects...", m.spinner.View(), len(m.projects))) + "\n\n")
+	case m.licenses.reports == nil:
+		b.WriteString(FancyBoxStyle.Render(fmt.Sprintf("No license reports.\n\nPress '%s' to check again.", m.keyMap.Scan.Help().Key)) + "\n\n")
+	case m.licenses.open:
+		b.WriteString(m.viewLicenseEntries())
+	default:
+		b.WriteString(m.viewLicenseProjects())
+	}
+
+	if m.statusMsg != "" {
+		b.WriteString(StatusStyle.Render(m.statusMsg) + "\n\n")
+	}
+	if m.error != "" {
+		b.WriteString(ErrorStyle.Render("Error: "+m.error) + "\n\n")
+	}
+
+	help := m.helpView()
+	b.WriteString(help)
+
+	return BaseStyle.Render(b.String())
+}
+
+// licenseCounts describes how many packages are denied or unknown
+func licenseCounts(denied, unknown, total int) string {
+	if denied == 0 && unknown == 0 {
+		return fmt.Sprintf("✓ %d packages allowed", total)
+	}
+	return fmt.Sprintf("✗ %d denied, %d unknown of %d", denied, unknown, total)
+}
+
+// viewLicenseProjects renders the combined row, each project's counts and the deny list
+func (m Model) viewLicenseProjects() string {
+	var b strings.Builder
+
+	deny := "nothing"
+	if len(m.config.LicenseDeny) > 0 {
+		deny = strings.Join(m.config.LicenseDeny, ", ")
+	}
+	b.WriteString(StatusStyle.Render("Denied: "+deny) + "\n\n")
+
+	total := len(m.licenses.reports) + 1
+	start, end := listWindow(m.licenses.cursor, total, licenseRows)
+	var rows []string
+	for i := start; i < end; i++ {
+		var row string
+		if i == 0 {
+			denied, unknown := 0, 0
+			for _, usage := range m.licenses.usages {
+				switch usage.Status {
+				case licenses.Denied:
+					denied++
+				case licenses.Unknown:
+					unknown++
+				}
+			}
+			row = fmt.Sprintf("%-24s %s", "All projects", licenseCounts(denied, unknown, len(m.licenses.usages)))
+		} else {
+			report := m.licenses.reports[i-1]
+			result := licenseCounts(report.Count(licenses.Denied), report.Count(licenses.Unknown), len(report.Entries))
+			if report.Skipped != "" {
+				result = "skipped, " + report.Skipped
+			}
+			row = fmt.Sprintf("%-24s %s", truncate(report.Project.Name, 24), result)
+		}
+		if i == m.licenses.cursor {
+			rows = append(rows, SelectedProjectStyle.Render(" > "+row))
+		} else {
+			rows = append(rows, ProjectStyle.Render("   "+row))
+		}
+	}
+	if total > licenseRows {
+		rows = append(rows, StatusStyle.Render(fmt.Sprintf("   %d-%d of %d", start+1, end, total)))
+	}
+	b.WriteString(ProjectListStyle.Render(strings.Join(rows, "\n")) + "\n\n")
+
+	if m.licenses.cursor > 0 {
+		own := m.licenses.reports[m.licenses.cursor-1].Own
+		b.WriteString(fmt.Sprintf("Own license: %s %s\n\n", licenseStyle(own.Status).Render(licenseName(own)), StatusStyle.Render("("+own.From+")")))
+	}
+	return b.String()
+}
+
+// viewLicenseEntries renders the open row's packages with details of the highlighted one
+func (m Model) viewLicenseEntries() string {
+	var b strings.Builder
+	usages := m.licenseEntries()
+
+	start, end := listWindow(m.licenses.entryCursor, len(usages), licenseRows)
+	var rows []string
+	for i := start; i < end; i++ {
+		usage := usages[i]
+		row := fmt.Sprintf("%-8s %-22s %s", usage.Status, truncate(usage.Package+" "+usage.Version, 22), truncate(licenseName(usage.Entry), 24))
+		if i == m.licenses.entryCursor {
+			rows = append(rows, SelectedProjectStyle.Render(" > "+row))
+		} else {
+			rows = append(rows, ProjectStyle.Render("   "+row))
+		}
+	}
+	if len(usages) > licenseRows {
+		rows = append(rows, StatusStyle.Render(fmt.Sprintf("   %d-%d of %d packages", start+1, end, len(usages))))
+	}
+	b.WriteString(ProjectListStyle.Render(strings.Join(rows, "\n")) + "\n\n")
+
+	usage := usages[m.licenses.entryCursor]
+	detail := []string{licenseStyle(usage.Status).Render(licenseName(usage.Entry)) + " " + StatusStyle.Render("from "+usage.From)}
+	if len(usage.Denied) > 0 {
+		detail = append(detail, "Denied by "+strings.Join(usage.Denied, ", "))
+	}
+	if usage.Status == licenses.Unknown && usage.Declared != "" {
+		declared, _, _ := strings.Cut(strings.TrimSpace(usage.Declared), "\n")
+		detail = append(detail, StatusStyle.Render("Declared as "+truncate(declared, 60)))
+	}
+	if len(usage.Projects) > 0 {
+		detail = append(detail, fmt.Sprintf("Used by %d projects: %s", len(usage.Projects), truncate(strings.Join(usage.Projects, ", "), 60)))
+	}
+	b.WriteString(strings.Join(detail, "\n") + "\n\n")
+	return b.String()
+}
@@ -0,0 +1,288 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/chloebubble/tuv/pkg/jobs"
+	"github.com/chloebubble/tuv/pkg/migrate"
+	"github.com/chloebubble/tuv/pkg/scanner"
+)
+
+// maxMigrateLines is how much of the migration output the migrate screen shows
+const maxMigrateLines = 15
+
+// diffHeight is how many lines of the pyproject.toml preview fit on the migrate screen
+const diffHeight = 16
+
+// migrateState holds the migrate screen's project and the outcome of its migration
+type migrateState struct {
+	project scanner.UVProject
+	plan    *migrate.Plan
+	diff    []migrate.DiffLine
+	offset  int
+	pending bool
+	done    bool
+	output  string
+	back    AppState
+}
+
+// migrationSteps describes what moving a project from its current tool to uv involves
+func migrationSteps(tool scanner.Tool) []string {
+	switch tool {
+	case scanner.ToolPoetry:
+		return []string{
+			"Move [tool.poetry] metadata and dependencies into [project]",
+			"Move Poetry dependency groups into [dependency-groups]",
+			"Keep the originals as .bak files and run uv lock to verify, restoring them if it fails",
+		}
+	case scanner.ToolPDM:
+		return []string{
+			"Download the third-party migrate-to-uv tool from PyPI and run it with uv tool run",
+			"migrate-to-uv moves [tool.pdm] settings and dev dependencies into [project] and [dependency-groups]",
+			"migrate-to-uv removes pdm.lock and creates uv.lock; tuv keeps no backups, so there's no preview or undo",
+		}
+	case scanner.ToolHatch:
+		return []string{
+			"Keep the existing [project] metadata and Hatch build settings",
+			"Create uv.lock with uv lock",
+		}
+	case scanner.ToolRequirements:
+		return []string{
+			"Add the requirements to pyproject.toml as [project] dependencies",
+			"Turn requirements-<group>.txt files into [dependency-groups]",
+			"Keep the originals as .bak files and run uv lock to verify, restoring them if it fails",
+		}
+	default:
+		return nil
+	}
+}
+
+// canMigrate reports whether the highlighted project is managed by something other than uv
+func (m Model) canMigrate() bool {
+	project, ok := m.currentProject()
+	return ok && project.Tool != scanner.ToolUV
+}
+
+// openMigrate shows what migrating a non-uv project involves and waits for confirmation
+func (m Model) openMigrate(project scanner.UVProject) (tea.Model, tea.Cmd) {
+	if project.Tool == scanner.ToolUV {
+		return m, nil
+	}
+	m.migrate = migrateState{project: project, back: m.state}
+	m.state = StateMigrate
+	m.error = ""
+
+	// Poetry and requirements.txt projects are converted here, so the change can be previewed first
+	if migrate.Supported(project.Tool) {
+		plan, err := migrate.Prepare(project.Path, project.Tool)
+		if err != nil {
+			m.error = "Can't convert this project: " + err.Error()
+			return m, nil
+		}
+		m.migrate.plan = plan
+		m.migrate.diff = migrate.Diff(plan.Before, plan.After, 2)
+	}
+	return m, nil
+}
+
+// canRunMigrate reports whether the migrate screen is waiting for confirmation
+func (s migrateState) canRunMigrate() bool {
+	return !s.pending && !s.done && (s.plan != nil || !migrate.Supported(s.project.Tool))
+}
+
+// runMigrate migrates the project on the migrate screen in the background
+func (m Model) runMigrate() (tea.Model, tea.Cmd) {
+	project := m.migrate.project
+	m.migrate.pending = true
+	m.error = ""
+
+	if plan := m.migrate.plan; plan != nil {
+		id := m.startJob("Migrate "+project.Name+" to uv", func(ctx context.Context, report jobs.Reporter) (tea.Msg, error) {
+			return convertProject(ctx, project, plan)
+		})
+		m.awaitJob(StateMigrate, id)
+		return m, nil
+	}
+
+	id := m.startJob("Migrate "+project.Name+" to uv", func(ctx context.Context, report jobs.Reporter) (tea.Msg, error) {
+		output, err := scanner.MigrateToUV(ctx, project.Path, project.Tool)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			return nil, errors.New(failureSummary(err.Error(), output))
+		}
+		updated, _ := scanner.ScanProject(project.Path)
+		return migrateDoneMsg{project: updated, output: output}, nil
+	})
+	m.awaitJob(StateMigrate, id)
+	return m, nil
+}
+
+// convertProject applies a migration plan and checks the result with uv lock, putting the
+// original files back if uv can't lock the converted project
+func convertProject(ctx context.Context, project scanner.UVProject, plan *migrate.Plan) (tea.Msg, error) {
+	if err := plan.Apply(); err != nil {
+		return nil, err
+	}
+	output, err := scanner.LockProject(ctx, project.Path)
+	if err != nil {
+		restoreErr := plan.Restore()
+		if ctx.Err() == nil {
+			err = fmt.Errorf("uv lock failed, the original files were restored: %s", failureSummary(err.Error(), output))
+		}
+		return nil, errors.Join(err, restoreErr)
+	}
+	updated, _ := scanner.ScanProject(project.Path)
+	return migrateDoneMsg{project: updated, output: output}, nil
+}
+
+// handleMigrateDone shows a finished migration and updates the project in the list
+func (m Model) handleMigrateDone(msg migrateDoneMsg) (tea.Model, tea.Cmd) {
+	for i := range m.projects {
+		if m.projects[i].Path == msg.project.Path {
+			m.projects[i] = msg.project
+		}
+	}
+	if msg.project.Path == m.migrate.project.Path {
+		m.migrate.project = msg.project
+		m.migrate.pending = false
+		m.migrate.done = true
+		m.migrate.output = msg.output
+	}
+	m.statusMsg = fmt.Sprintf("Migrated %s to uv", msg.project.Name)
+	return m, nil
+}
+
+// updateMigrate handles updates in the migrate state
+func (m Model) updateMigrate(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keyMap.Select):
+			if m.migrate.done {
+				m.state = m.migrate.back
+				return m, nil
+			}
+			if !m.migrate.canRunMigrate() {
+				return m, nil
+			}
+			return m.runMigrate()
+
+		case key.Matches(msg, m.keyMap.Up):
+			if m.migrate.offset > 0 {
+				m.migrate.offset--
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Down):
+			if m.migrate.offset < len(m.migrate.diff)-diffHeight {
+				m.migrate.offset++
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Back):
+			m.state = m.migrate.back
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
+
+// viewMigrateDiff renders the visible part of the pyproject.toml preview
+func (m Model) viewMigrateDiff() string {
+	diff := m.migrate.diff
+	end := min(m.migrate.offset+diffHeight, len(diff))
+
+	var rows []string
+	for _, line := range diff[m.migrate.offset:end] {
+		switch line.Kind {
+		case migrate.DiffAdded:
+			rows = append(rows, SuccessStyle.Render("+ "+line.Text))
+		case migrate.DiffRemoved:
+			rows = append(rows, ErrorStyle.Render("- "+line.Text))
+		case migrate.DiffGap:
+			rows = append(rows, StatusStyle.Render("  ⋯"))
+		default:
+			rows = append(rows, StatusStyle.Render("  "+line.Text))
+		}
+	}
+
+	title := "pyproject.toml"
+	if len(diff) > diffHeight {
+		title += fmt.Sprintf(" (lines %d-%d of %d)", m.migrate.offset+1, end, len(diff))
+	}
+	return InputLabelStyle.Render(title) + "\n" + ProjectListStyle.Render(strings.Join(rows, "\n"))
+}
+
+// toolBadge renders the marker shown next to projects that are not managed by uv
+func toolBadge(tool scanner.Tool) string {
+	if tool == scanner.ToolUV {
+		return ""
+	}
+	return WarningStyle.Render("[" + tool.Label() + "]")
+}
+
+// viewMigrate renders the migration guide and its result
+func (m Model) viewMigrate() string {
+	var b strings.Builder
+	project := m.migrate.project
+
+	title := TitleStyle.Render("Migrate " + project.Name + " to uv")
+	b.WriteString(title + "\n")
+
+	divider := lipgloss.NewStyle().
+		Foreground(secondaryColor).
+		Render("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	b.WriteString(divider + "\n\n")
+
+	switch {
+	case m.migrate.pending:
+		b.WriteString(FancyBoxStyle.Render(fmt.Sprintf("%s Migrating from %s...", m.spinner.View(), project.Tool.Label())) + "\n\n")
+	case m.migrate.done:
+		result := SuccessStyle.Render("✓ " + project.Name + " is now managed by uv")
+		if m.migrate.plan != nil {
+			result += "\n" + StatusStyle.Render("Originals kept as "+strings.Join(m.migrate.plan.Backups(), ", "))
+		}
+		if lines := strings.Split(strings.TrimSpace(m.migrate.output), "\n"); m.migrate.output != "" {
+			lines = lines[max(len(lines)-maxMigrateLines, 0):]
+			result += "\n\n" + StatusStyle.Render(strings.Join(lines, "\n"))
+		}
+		b.WriteString(FancyBoxStyle.Render(result) + "\n\n")
+	default:
+		guide := fmt.Sprintf("Detected %s. Migrating will:\n", HighlightStyle.Render(project.Tool.Label()))
+		for i, step := range migrationSteps(project.Tool) {
+			guide += fmt.Sprintf("\n  %d. %s", i+1, step)
+		}
+		b.WriteString(FancyBoxStyle.Render(guide) + "\n\n")
+		if plan := m.migrate.plan; plan != nil {
+			b.WriteString(m.viewMigrateDiff() + "\n\n")
+			if len(plan.Retire) > 0 {
+				b.WriteString(StatusStyle.Render("Moved aside: "+strings.Join(plan.Retire, ", ")) + "\n")
+			}
+			for _, note := range plan.Notes {
+				b.WriteString(WarningStyle.Render("! "+note) + "\n")
+			}
+			b.WriteString("\n")
+		}
+		if project.Git == nil || project.Git.Dirty {
+			b.WriteString(WarningStyle.Render("Files are changed in place; commit or back up the project first.") + "\n\n")
+		}
+	}
+
+	if m.error != "" {
+		b.WriteString(ErrorStyle.Render("Error: "+m.error) + "\n\n")
+	}
+
+	help := m.helpView()
+	b.WriteString(help)
+
+	return BaseStyle.Render(b.String())
+}
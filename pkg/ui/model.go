@@ -1,19 +1,30 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/chloebubble/tuv/pkg/archive"
+	"github.com/chloebubble/tuv/pkg/audit"
 	"github.com/chloebubble/tuv/pkg/config"
+	"github.com/chloebubble/tuv/pkg/health"
+	"github.com/chloebubble/tuv/pkg/jobs"
+	"github.com/chloebubble/tuv/pkg/licenses"
+	"github.com/chloebubble/tuv/pkg/runner"
 	"github.com/chloebubble/tuv/pkg/scanner"
+	"github.com/chloebubble/tuv/pkg/testrun"
+	"github.com/chloebubble/tuv/pkg/watcher"
 )
 
 // AppState represents the current state of the application
@@ -26,53 +37,36 @@ const (
 	StateProjectDetail
 	StateNewProject
 	StateLoading
+	StateSelectFilter
+	StateBulkMenu
+	StateBulkConfirm
+	StateBulkReport
+	StateClean
+	StateStale
+	StateArchives
+	StateDiskUsage
+	StateRun
+	StateJobs
+	StateTests
+	StateThemes
+	StateSettings
+	StateSettingsEdit
+	StateMigrate
+	StateHealth
+	StateAudit
+	StateLicenses
+	StateExport
+
+	// stateCount is the number of states and must stay last
+	stateCount
 )
 
-// KeyMap defines the keybindings for the application
-type KeyMap struct {
-	Up     key.Binding
-	Down   key.Binding
-	Select key.Binding
-	Back   key.Binding
-	Quit   key.Binding
-	Scan   key.Binding
-}
-
-// DefaultKeyMap returns the default keybindings
-func DefaultKeyMap() KeyMap {
-	return KeyMap{
-		Up: key.NewBinding(
-			key.WithKeys("up", "k"),
-			key.WithHelp("↑/k", "up"),
-		),
-		Down: key.NewBinding(
-			key.WithKeys("down", "j"),
-			key.WithHelp("↓/j", "down"),
-		),
-		Select: key.NewBinding(
-			key.WithKeys("enter"),
-			key.WithHelp("enter", "select"),
-		),
-		Back: key.NewBinding(
-			key.WithKeys("esc"),
-			key.WithHelp("esc", "back"),
-		),
-		Quit: key.NewBinding(
-			key.WithKeys("ctrl+c", "q"),
-			key.WithHelp("ctrl+c/q", "quit"),
-		),
-		Scan: key.NewBinding(
-			key.WithKeys("s"),
-			key.WithHelp("s", "scan"),
-		),
-	}
-}
-
 // Model represents the main application model
 type Model struct {
 	config          *config.Config
 	scanner         *scanner.Scanner
 	keyMap          KeyMap
+	help            help.Model
 	state           AppState
 	width           int
 	height          int
@@ -80,7 +74,46 @@ type Model struct {
 	selectedMenu    int
 	projects        []scanner.UVProject
 	selectedProject int
+	dirtyOnly       bool
+	expanded        map[string]bool
+	detailTab       int
+	selected        map[string]bool
 	textInput       textinput.Model
+	filterInput     textinput.Model
+	bulkMenu        int
+	bulkTargets     []scanner.UVProject
+	bulkResults     []bulkResult
+	bulkAction      bulkAction
+	bulkRun         int
+	clean           cleanState
+	stale           []staleEntry
+	staleCursor     int
+	staleConfirm    string
+	archives        []archive.Entry
+	archiveCursor   int
+	usage           usageState
+	watcher         *watcher.Watcher
+	watchCmd        tea.Cmd
+	scripts         []scanner.Runnable
+	scriptCursor    int
+	run             runState
+	runHistory      *runner.History
+	testResults     *testrun.Results
+	tests           testState
+	migrate         migrateState
+	health          healthState
+	audit           auditState
+	licenses        licensesState
+	export          exportState
+	jobs            *jobs.Manager
+	jobsBack        AppState
+	waiting         map[AppState]int
+	jobCursor       int
+	notice          string
+	stalePending    bool
+	archivesPending bool
+	themes          themeState
+	settings        settingsState
 	spinner         spinner.Model
 	loading         bool
 	loadingMsg      string
@@ -110,9 +143,21 @@ func NewModel(cfg *config.Config) Model {
 		}
 	}
 
+	fi := textinput.New()
+	fi.Placeholder = "name or glob, e.g. api-*"
+	fi.CharLimit = 256
+	fi.Width = 50
+
 	menuItems := []string{
 		"List projects",
 		"New project",
+		"Stale projects",
+		"Health",
+		"Audit",
+		"Licenses",
+		"Archives",
+		"Jobs",
+		"Settings",
 		"Quit",
 	}
 
@@ -126,23 +171,54 @@ func NewModel(cfg *config.Config) Model {
 
 	scn := scanner.NewScanner(cfg.ParentDirectory)
 
+	// A missing or unreadable history just starts empty
+	history, _ := runner.LoadHistory(filepath.Join(config.DataDir(), "runs.json"))
+	testResults, _ := testrun.LoadResults(filepath.Join(config.DataDir(), "tests.json"))
+
+	// Bad keybindings fall back to the defaults so the app stays usable
+	keyMap, keyErr := NewKeyMap(cfg.Keys)
+	if keyErr != nil {
+		keyMap = DefaultKeyMap()
+	}
+
 	m := Model{
 		config:          cfg,
 		scanner:         scn,
-		keyMap:          DefaultKeyMap(),
+		keyMap:          keyMap,
+		help:            newHelp(),
 		state:           initialState,
 		menuItems:       menuItems,
 		selectedMenu:    0,
 		selectedProject: 0,
+		selected:        make(map[string]bool),
+		expanded:        make(map[string]bool),
 		textInput:       ti,
+		filterInput:     fi,
+		runHistory:      history,
+		testResults:     testResults,
+		jobs:            jobs.NewManager(cfg.MaxJobs),
+		waiting:         make(map[AppState]int),
 		spinner:         s,
 		loading:         false,
 	}
+	if keyErr != nil {
+		m.error = "Invalid keys in config, using defaults: " + keyErr.Error()
+	}
+	if cfg.MigratedFrom > 0 {
+		m.statusMsg = fmt.Sprintf("Upgraded config from version %d, the old file is at %s", cfg.MigratedFrom, cfg.BackupLocation)
+	}
+	// The scan would fail anyway, but say which setting to check before it does
+	for _, warning := range cfg.Warnings() {
+		m.statusMsg = strings.TrimPrefix(m.statusMsg+"\nWarning: "+warning, "\n")
+	}
+	if err := m.loadTheme(); err != nil {
+		m.error = "Could not load theme: " + err.Error()
+	}
 
 	// Always scan on startup if not in first run state
 	if initialState != StateFirstRun {
-		m.loading = true
-		m.loadingMsg = "Scanning for uv projects..."
+		m.scanProjects("")
+		m.watchCmd = m.startWatcher()
 	}
 
 	return m
@@ -152,22 +228,38 @@ func NewModel(cfg *config.Config) Model {
 func (m Model) Init() tea.Cmd {
 	cmds := []tea.Cmd{
 		m.spinner.Tick,
+		waitForJobEvent(m.jobs),
 	}
 
-	if m.loading {
-		cmds = append(cmds, m.scanProjects)
+	if m.watchCmd != nil {
+		cmds = append(cmds, m.watchCmd)
 	}
 
 	return tea.Batch(cmds...)
 }
 
-// scanProjects scans for uv projects
-func (m Model) scanProjects() tea.Msg {
-	projects, err := m.scanner.ScanProjects()
-	if err != nil {
-		return errMsg{err}
-	}
-	return projectsFoundMsg{projects}
+// scanProjects queues a scan for uv projects. A non-empty status replaces the project count
+// in the status line, so actions can report their outcome after the rescan.
+func (m *Model) scanProjects(status string) {
+	m.loading = true
+	m.loadingMsg = "Scanning for uv projects..."
+
+	scn := m.scanner
+	id := m.startJob("Scan projects", func(ctx context.Context, report jobs.Reporter) (tea.Msg, error) {
+		projects, err := scn.Scan(ctx, func(done, total int) {
+			report(float64(done)/float64(total), fmt.Sprintf("%d of %d directories", done, total))
+		})
+		if err != nil {
+			return nil, err
+		}
+		return projectsFoundMsg{projects: projects, status: status}, nil
+	})
+	m.awaitJob(StateLoading, id)
+}
+
+// inTextInput reports whether a screen is typing into a text field
+func inTextInput(state AppState) bool {
+	return state == StateFirstRun || state == StateNewProject || state == StateSelectFilter || state == StateSettingsEdit
 }
 
 // Update handles updates to the model
@@ -176,9 +268,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// Printable quit keys have to reach text fields as ordinary input
+		typing := inTextInput(m.state) && msg.Type == tea.KeyRunes
 		switch {
-		case key.Matches(msg, m.keyMap.Quit):
+		case key.Matches(msg, m.keyMap.Quit) && !typing:
 			return m, tea.Quit
+		case key.Matches(msg, m.keyMap.Jobs) && !inTextInput(m.state):
+			return m.openJobs()
 		}
 
 		switch m.state {
@@ -192,6 +288,44 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.updateProjectDetail(msg)
 		case StateNewProject:
 			return m.updateNewProject(msg)
+		case StateSelectFilter:
+			return m.updateSelectFilter(msg)
+		case StateBulkMenu:
+			return m.updateBulkMenu(msg)
+		case StateBulkConfirm:
+			return m.updateBulkConfirm(msg)
+		case StateBulkReport:
+			return m.updateBulkReport(msg)
+		case StateClean:
+			return m.updateClean(msg)
+		case StateStale:
+			return m.updateStale(msg)
+		case StateArchives:
+			return m.updateArchives(msg)
+		case StateDiskUsage:
+			return m.updateDiskUsage(msg)
+		case StateRun:
+			return m.updateRun(msg)
+		case StateJobs:
+			return m.updateJobs(msg)
+		case StateTests:
+			return m.updateTests(msg)
+		case StateThemes:
+			return m.updateThemes(msg)
+		case StateSettings:
+			return m.updateSettings(msg)
+		case StateSettingsEdit:
+			return m.updateSettingsEdit(msg)
+		case StateMigrate:
+			return m.updateMigrate(msg)
+		case StateHealth:
+			return m.updateHealth(msg)
+		case StateAudit:
+			return m.updateAudit(msg)
+		case StateLicenses:
+			return m.updateLicenses(msg)
+		case StateExport:
+			return m.updateExport(msg)
 		case StateLoading:
 			// If we're in the loading state, just return
 			return m, nil
@@ -209,20 +343,124 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case projectsFoundMsg:
 		m.projects = msg.projects
 		m.loading = false
-		m.statusMsg = fmt.Sprintf("Found %d uv projects", len(m.projects))
+		m.statusMsg = fmt.Sprintf("Found %d projects", len(m.projects))
+		if msg.status != "" {
+			m.statusMsg = msg.status
+		}
+		m.pruneSelection()
+		if m.selectedProject >= len(m.visibleProjects()) {
+			m.selectedProject = max(len(m.visibleProjects())-1, 0)
+		}
 
-		// If no projects were found and we're not in the main menu, go to main menu
-		if len(m.projects) == 0 && m.state != StateMainMenu {
+		// If no projects were found while browsing them, go to main menu
+		if len(m.projects) == 0 && (m.state == StateProjectList || m.state == StateProjectDetail) {
 			m.state = StateMainMenu
 		}
 
 	case projectCreatedMsg:
 		m.projects = msg.projects
-		m.loading = false
-		m.state = StateMainMenu
 		m.statusMsg = fmt.Sprintf("Project '%s' created successfully!", msg.projectName)
-		// Reset text input for next use
-		m.textInput.SetValue("")
+
+	case bulkStepMsg:
+		return m.handleBulkStep(msg)
+
+	case cleanScanMsg:
+		// Ignore measurements for a clean screen that has since been opened for other projects
+		if slices.Equal(msg.projects, projectPaths(m.clean.projects)) {
+			m.clean.found = msg.found
+			m.clean.pending = false
+		}
+
+	case cleanDoneMsg:
+		if slices.Equal(msg.projects, projectPaths(m.clean.projects)) {
+			m.clean.done = true
+			m.clean.pending = false
+			m.clean.freed = msg.freed
+			m.clean.failures = msg.failures
+		}
+		m.scanProjects(fmt.Sprintf("Freed %s", scanner.FormatSize(msg.freed)))
+
+	case staleFoundMsg:
+		m.stale = msg.entries
+		m.stalePending = false
+
+	case staleResolvedMsg:
+		m.removeStale(msg.path)
+		m.statusMsg = msg.status
+		for i := range m.projects {
+			if m.projects[i].Path == msg.path {
+				m.projects[i].HasVenv = false
+			}
+		}
+
+	case staleArchivedMsg:
+		m.removeStale(msg.path)
+		m.scanProjects(msg.status)
+
+	case jobEventMsg:
+		return m.handleJobEvent(msg)
+
+	case testFinishedMsg:
+		return m.handleTestFinished(msg)
+
+	case migrateDoneMsg:
+		return m.handleMigrateDone(msg)
+
+	case healthCheckedMsg:
+		sortFindings(msg.findings, m.health.sortBy)
+		m.health.findings = msg.findings
+		m.health.checked = msg.checked
+		m.health.pending = false
+
+	case healthFixedMsg:
+		return m.handleHealthFixed(msg)
+
+	case driftResolvedMsg:
+		return m.handleDriftResolved(msg)
+
+	case auditDoneMsg:
+		return m.handleAuditDone(msg)
+
+	case licensesCheckedMsg:
+		return m.handleLicensesChecked(msg)
+
+	case exportDoneMsg:
+		return m.handleExportDone(msg)
+
+	case runOutputMsg:
+		return m.handleRunOutput(msg)
+
+	case runFinishedMsg:
+		return m.handleRunFinished(msg)
+
+	case execFinishedMsg:
+		return m.handleExecFinished(msg)
+
+	case projectsChangedMsg:
+		return m.applyProjectChanges(msg)
+
+	case dirUsageMsg:
+		// Ignore results for a directory we have already navigated away from
+		if m.state == StateDiskUsage && msg.path == m.usage.dirs[len(m.usage.dirs)-1] {
+			m.usage.entries = msg.entries
+			m.usage.pending = false
+		}
+
+	case packageUsageMsg:
+		if msg.project == m.usage.project.Path {
+			m.usage.packages = msg.packages
+		}
+
+	case archivesFoundMsg:
+		m.archives = msg.entries
+		m.archivesPending = false
+		if m.archiveCursor >= len(m.archives) {
+			m.archiveCursor = max(len(m.archives)-1, 0)
+		}
+
+	case archiveRestoredMsg:
+		m.scanProjects(msg.status)
+		m.listArchives()
 
 	case statusMsg:
 		m.statusMsg = msg.msg
@@ -230,7 +468,6 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case errMsg:
 		m.error = msg.err.Error()
-		m.loading = false
 	}
 
 	return m, tea.Batch(cmds...)
@@ -257,9 +494,8 @@ func (m Model) updateMainMenu(msg tea.Msg) (tea.Model, tea.Cmd) {
 			switch m.selectedMenu {
 			case 0: // List projects
 				if len(m.projects) == 0 {
-					m.loading = true
-					m.loadingMsg = "Scanning for uv projects..."
-					return m, m.scanProjects
+					m.scanProjects("")
+					return m, nil
 				}
 				m.state = StateProjectList
 				return m, nil
@@ -270,14 +506,33 @@ func (m Model) updateMainMenu(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.textInput.Focus()
 				return m, nil
 
-			case 2: // Quit
+			case 2: // Stale projects
+				return m.openStale()
+
+			case 3: // Health
+				return m.openHealth()
+
+			case 4: // Audit
+				return m.openAudit()
+
+			case 5: // Licenses
+				return m.openLicenses()
+
+			case 6: // Archives
+				return m.openArchives()
+
+			case 7: // Jobs
+				return m.openJobs()
+
+			case 8: // Settings
+				return m.openSettings()
+
+			case 9: // Quit
 				return m, tea.Quit
 			}
-		case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
-			// Rescan for projects when 'r' is pressed
-			m.loading = true
-			m.loadingMsg = "Scanning for uv projects..."
-			return m, m.scanProjects
+		case key.Matches(msg, m.keyMap.Scan):
+			m.scanProjects("")
+			return m, nil
 		}
 	}
 
@@ -357,9 +612,8 @@ func (m Model) updateFirstRun(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 				m.scanner = scanner.NewScanner(m.config.ParentDirectory)
 				m.state = StateMainMenu
-				m.loading = true
-				m.loadingMsg = "Scanning for uv projects..."
-				return m, m.scanProjects
+				m.scanProjects("")
+				return m, m.startWatcher()
 			}
 		}
 	}
@@ -394,9 +648,8 @@ func (m Model) useDefaultDirectory() (tea.Model, tea.Cmd) {
 
 	m.scanner = scanner.NewScanner(m.config.ParentDirectory)
 	m.state = StateMainMenu
-	m.loading = true
-	m.loadingMsg = "Scanning for uv projects..."
-	return m, m.scanProjects
+	m.scanProjects("")
+	return m, m.startWatcher()
 }
 
 // updateProjectList handles updates in the project list state
@@ -411,14 +664,16 @@ func (m Model) updateProjectList(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 
 		case key.Matches(msg, m.keyMap.Down):
-			if m.selectedProject < len(m.projects)-1 {
+			if m.selectedProject < len(m.visibleProjects())-1 {
 				m.selectedProject++
 			}
 			return m, nil
 
 		case key.Matches(msg, m.keyMap.Select):
-			if len(m.projects) > 0 {
+			if project, ok := m.currentProject(); ok {
 				m.state = StateProjectDetail
+				m.detailTab = 0
+				m.loadScripts(project)
 			}
 			return m, nil
 
@@ -427,15 +682,129 @@ func (m Model) updateProjectList(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 
 		case key.Matches(msg, m.keyMap.Scan):
-			m.loading = true
-			m.loadingMsg = "Scanning for uv projects..."
-			return m, m.scanProjects
+			m.scanProjects("")
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Toggle):
+			if project, ok := m.currentProject(); ok {
+				if m.selected[project.Path] {
+					delete(m.selected, project.Path)
+				} else {
+					m.selected[project.Path] = true
+				}
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.SelectAll):
+			for _, project := range m.visibleProjects() {
+				m.selected[project.Path] = true
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Invert):
+			for _, project := range m.visibleProjects() {
+				if m.selected[project.Path] {
+					delete(m.selected, project.Path)
+				} else {
+					m.selected[project.Path] = true
+				}
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Expand):
+			return m.toggleWorkspace(true), nil
+
+		case key.Matches(msg, m.keyMap.Collapse):
+			return m.toggleWorkspace(false), nil
+
+		case key.Matches(msg, m.keyMap.Filter):
+			m.state = StateSelectFilter
+			m.filterInput.SetValue("")
+			m.filterInput.Focus()
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Actions):
+			return m.openBulkMenu()
+
+		case key.Matches(msg, m.keyMap.Clean):
+			if project, ok := m.currentProject(); ok {
+				return m.openClean([]scanner.UVProject{project})
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.DirtyOnly):
+			m.dirtyOnly = !m.dirtyOnly
+			m.selectedProject = 0
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Edit):
+			if project, ok := m.currentProject(); ok {
+				return m.openInEditor(project)
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Shell):
+			if project, ok := m.currentProject(); ok {
+				return m.openShell(project)
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Open):
+			if project, ok := m.currentProject(); ok {
+				return m.openFileManager(project)
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Test):
+			if project, ok := m.currentProject(); ok {
+				return m.runTests(project)
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Migrate):
+			if project, ok := m.currentProject(); ok {
+				return m.openMigrate(project)
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Fix):
+			if project, ok := m.currentProject(); ok {
+				return m.resolveDrift(project)
+			}
+			return m, nil
 		}
 	}
 
 	return m, nil
 }
 
+// detailTabs are the sections of the project detail view
+var detailTabs = []string{"Overview", "Git", "Scripts"}
+
+// visibleProjects returns the projects shown in the list after filtering
+func (m Model) visibleProjects() []scanner.UVProject {
+	var projects []scanner.UVProject
+	for _, project := range m.allProjects() {
+		if project.IsWorkspaceMember() && !m.expanded[project.Workspace] {
+			continue
+		}
+		if m.dirtyOnly && (project.Git == nil || !project.Git.Dirty) {
+			continue
+		}
+		projects = append(projects, project)
+	}
+	return projects
+}
+
+// currentProject returns the highlighted project in the list
+func (m Model) currentProject() (scanner.UVProject, bool) {
+	projects := m.visibleProjects()
+	if m.selectedProject < 0 || m.selectedProject >= len(projects) {
+		return scanner.UVProject{}, false
+	}
+	return projects[m.selectedProject], true
+}
+
 // updateProjectDetail handles updates in the project detail state
 func (m Model) updateProjectDetail(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -444,6 +813,61 @@ func (m Model) updateProjectDetail(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.keyMap.Back):
 			m.state = StateProjectList
 			return m, nil
+
+		case key.Matches(msg, m.keyMap.NextTab):
+			m.detailTab = (m.detailTab + 1) % len(detailTabs)
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Up):
+			if detailTabs[m.detailTab] == "Scripts" && m.scriptCursor > 0 {
+				m.scriptCursor--
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Down):
+			if detailTabs[m.detailTab] == "Scripts" && m.scriptCursor < len(m.scripts)-1 {
+				m.scriptCursor++
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Select):
+			if detailTabs[m.detailTab] == "Scripts" && len(m.scripts) > 0 {
+				project, _ := m.currentProject()
+				return m.startRun(project, m.scripts[m.scriptCursor])
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Clean):
+			project, _ := m.currentProject()
+			return m.openClean([]scanner.UVProject{project})
+
+		case key.Matches(msg, m.keyMap.Usage):
+			project, _ := m.currentProject()
+			return m.openUsage(project)
+
+		case key.Matches(msg, m.keyMap.Edit):
+			project, _ := m.currentProject()
+			return m.openInEditor(project)
+
+		case key.Matches(msg, m.keyMap.Shell):
+			project, _ := m.currentProject()
+			return m.openShell(project)
+
+		case key.Matches(msg, m.keyMap.Open):
+			project, _ := m.currentProject()
+			return m.openFileManager(project)
+
+		case key.Matches(msg, m.keyMap.Test):
+			project, _ := m.currentProject()
+			return m.runTests(project)
+
+		case key.Matches(msg, m.keyMap.Migrate):
+			project, _ := m.currentProject()
+			return m.openMigrate(project)
+
+		case key.Matches(msg, m.keyMap.Fix):
+			project, _ := m.currentProject()
+			return m.resolveDrift(project)
 		}
 	}
 
@@ -473,18 +897,19 @@ func (m Model) updateNewProject(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m, nil
 				}
 
-				m.loading = true
-				m.loadingMsg = fmt.Sprintf("Creating new project: %s", projectName)
-
-				return m, func() tea.Msg {
+				// Creation continues in the background while the user keeps navigating
+				m.state = StateMainMenu
+				m.textInput.SetValue("")
+				scn := m.scanner
+				m.startJob("Create project "+projectName, func(ctx context.Context, report jobs.Reporter) (tea.Msg, error) {
 					// Create project directory
 					if err := os.MkdirAll(projectPath, 0755); err != nil {
-						return errMsg{err}
+						return nil, err
 					}
 
 					// Create virtual environment
-					if _, err := scanner.RunUVCommand(projectPath, "venv"); err != nil {
-						return errMsg{err}
+					if _, err := scanner.RunUVCommandContext(ctx, projectPath, "venv"); err != nil {
+						return nil, err
 					}
 
 					// Create a basic pyproject.toml file
@@ -502,13 +927,13 @@ build-backend = "hatchling.build"
 `, projectName)
 
 					if err := os.WriteFile(filepath.Join(projectPath, "pyproject.toml"), []byte(pyprojectContent), 0644); err != nil {
-						return errMsg{err}
+						return nil, err
 					}
 
 					// Create a .python-version file
 					// Get Python version from the virtual environment
 					pythonVersion := "3.12" // Default version if we can't detect it
-					if output, err := scanner.RunUVCommand(projectPath, "venv", "python", "--version"); err == nil {
+					if output, err := scanner.RunUVCommandContext(ctx, projectPath, "venv", "python", "--version"); err == nil {
 						// Parse output like "Python 3.12.0"
 						parts := strings.Split(strings.TrimSpace(output), " ")
 						if len(parts) >= 2 {
@@ -517,13 +942,13 @@ build-backend = "hatchling.build"
 					}
 
 					if err := os.WriteFile(filepath.Join(projectPath, ".python-version"), []byte(pythonVersion), 0644); err != nil {
-						return errMsg{err}
+						return nil, err
 					}
 
 					// Create a basic README.md file
 					readmeContent := fmt.Sprintf("# %s\n\nA new Python project created with TUV.\n", projectName)
 					if err := os.WriteFile(filepath.Join(projectPath, "README.md"), []byte(readmeContent), 0644); err != nil {
-						return errMsg{err}
+						return nil, err
 					}
 
 					// Create a basic Python file
@@ -534,21 +959,21 @@ if __name__ == "__main__":
     main()
 `
 					if err := os.WriteFile(filepath.Join(projectPath, "hello.py"), []byte(helloContent), 0644); err != nil {
-						return errMsg{err}
+						return nil, err
 					}
 
 					// Scan for projects to update the list
-					projects, err := m.scanner.ScanProjects()
+					projects, err := scn.Scan(ctx, nil)
 					if err != nil {
-						return errMsg{err}
+						return nil, err
 					}
 
-					// Return to main menu with success message
 					return projectCreatedMsg{
 						projects:    projects,
 						projectName: projectName,
-					}
-				}
+					}, nil
+				})
+				return m, nil
 			}
 		}
 	}
@@ -556,8 +981,17 @@ if __name__ == "__main__":
 	return m, cmd
 }
 
-// View renders the UI
+// View renders the UI with the background job status underneath
 func (m Model) View() string {
+	view := m.viewState()
+	if status := m.viewJobStatus(); status != "" && m.state != StateJobs {
+		view += "\n\n" + status
+	}
+	return view
+}
+
+// viewState renders the screen for the current state
+func (m Model) viewState() string {
 	switch m.state {
 	case StateMainMenu:
 		return m.viewMainMenu()
@@ -569,6 +1003,42 @@ func (m Model) View() string {
 		return m.viewProjectDetail()
 	case StateNewProject:
 		return m.viewNewProject()
+	case StateSelectFilter:
+		return m.viewSelectFilter()
+	case StateBulkMenu:
+		return m.viewBulkMenu()
+	case StateBulkConfirm:
+		return m.viewBulkConfirm()
+	case StateBulkReport:
+		return m.viewBulkReport()
+	case StateClean:
+		return m.viewClean()
+	case StateStale:
+		return m.viewStale()
+	case StateArchives:
+		return m.viewArchives()
+	case StateDiskUsage:
+		return m.viewDiskUsage()
+	case StateRun:
+		return m.viewRun()
+	case StateJobs:
+		return m.viewJobs()
+	case StateTests:
+		return m.viewTests()
+	case StateThemes:
+		return m.viewThemes()
+	case StateSettings, StateSettingsEdit:
+		return m.viewSettings()
+	case StateMigrate:
+		return m.viewMigrate()
+	case StateHealth:
+		return m.viewHealth()
+	case StateAudit:
+		return m.viewAudit()
+	case StateLicenses:
+		return m.viewLicenses()
+	case StateExport:
+		return m.viewExport()
 	case StateLoading:
 		return m.viewLoading()
 	default:
@@ -613,7 +1083,7 @@ func (m Model) viewMainMenu() string {
 		b.WriteString(ErrorStyle.Render("Error: "+m.error) + "\n")
 	}
 
-	help := HelpStyle.Render("↑/↓: Navigate • Enter: Select • s: Rescan • q: Quit")
+	help := m.helpView()
 	b.WriteString("\n" + help)
 
 	return BaseStyle.Render(b.String())
@@ -654,7 +1124,7 @@ func (m Model) viewFirstRun() string {
 	}
 
 	// Update help text to remove 'd' key option
-	help := HelpStyle.Render("Enter: Save Configuration • Ctrl+C: Quit")
+	help := m.helpView()
 	b.WriteString(help)
 
 	return BaseStyle.Render(b.String())
@@ -676,21 +1146,54 @@ func (m Model) viewProjectList() string {
 		Render("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	b.WriteString(divider + "\n\n")
 
+	visible := m.visibleProjects()
 	if m.loading {
 		loadingMsg := FancyBoxStyle.Render(fmt.Sprintf("%s %s", m.spinner.View(), m.loadingMsg))
 		b.WriteString(loadingMsg + "\n")
 	} else if len(m.projects) == 0 {
-		emptyMsg := FancyBoxStyle.Render("No uv projects found.\n\nPress 'r' to rescan for projects or 'Esc' to go back to the main menu.")
+		emptyMsg := FancyBoxStyle.Render(fmt.Sprintf("No Python projects found.\n\nPress '%s' to rescan for projects or '%s' to go back to the main menu.", m.keyMap.Scan.Help().Key, m.keyMap.Back.Help().Key))
+		b.WriteString(emptyMsg + "\n")
+	} else if len(visible) == 0 {
+		emptyMsg := FancyBoxStyle.Render(fmt.Sprintf("No projects with uncommitted changes.\n\nPress '%s' to show all projects.", m.keyMap.DirtyOnly.Help().Key))
 		b.WriteString(emptyMsg + "\n")
 	} else {
 		// Add project count with highlight
-		countMsg := fmt.Sprintf("Found %s uv projects", HighlightStyle.Render(fmt.Sprintf("%d", len(m.projects))))
+		countMsg := fmt.Sprintf("Found %s projects", HighlightStyle.Render(fmt.Sprintf("%d", len(m.projects))))
+		if m.dirtyOnly {
+			countMsg += fmt.Sprintf(" • %s dirty", HighlightStyle.Render(fmt.Sprintf("%d", len(visible))))
+		}
+		if len(m.selected) > 0 {
+			countMsg += fmt.Sprintf(" • %s selected", HighlightStyle.Render(fmt.Sprintf("%d", len(m.selected))))
+		}
 		b.WriteString(countMsg + "\n\n")
 
 		var rows []string
-		for i, project := range m.projects {
-			// Only show name and size
-			projectInfo := fmt.Sprintf("%s (%s)", project.Name, scanner.FormatSize(project.Size))
+		for i, project := range visible {
+			// Only show selection mark, name, size and git state
+			mark := "[ ]"
+			if m.selected[project.Path] {
+				mark = "[x]"
+			}
+			name := m.expandMarker(project) + project.Name
+			if project.IsWorkspaceMember() {
+				name = "  └ " + project.Name
+			}
+			projectInfo := fmt.Sprintf("%s %s (%s)", mark, name, scanner.FormatSize(project.Size))
+			if badge := toolBadge(project.Tool); badge != "" {
+				projectInfo += " " + badge
+			}
+			if project.IsWorkspaceRoot() {
+				projectInfo += " " + m.workspaceBadge(project)
+			}
+			if project.Drift != nil {
+				projectInfo += " " + driftBadge()
+			}
+			if project.Git != nil {
+				projectInfo += " ⎇ " + project.Git.Indicator()
+			}
+			if report := m.testResults.For(project.Path); report != nil {
+				projectInfo += " " + testBadge(report)
+			}
 			if i == m.selectedProject {
 				rows = append(rows, SelectedProjectStyle.Render(fmt.Sprintf(" > %s", projectInfo)))
 			} else {
@@ -712,7 +1215,7 @@ func (m Model) viewProjectList() string {
 		b.WriteString(ErrorStyle.Render("Error: "+m.error) + "\n")
 	}
 
-	help := HelpStyle.Render("↑/↓: Navigate • Enter: Select • r: Rescan • Esc: Back • q: Quit")
+	help := m.helpView()
 	b.WriteString("\n" + help)
 
 	return BaseStyle.Render(b.String())
@@ -721,7 +1224,7 @@ func (m Model) viewProjectList() string {
 // viewProjectDetail renders the project detail view
 func (m Model) viewProjectDetail() string {
 	var b strings.Builder
-	project := m.projects[m.selectedProject]
+	project, _ := m.currentProject()
 
 	// Add a compact logo
 	b.WriteString(GetCompactLogo() + "\n")
@@ -736,6 +1239,40 @@ func (m Model) viewProjectDetail() string {
 		Render("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	b.WriteString(divider + "\n\n")
 
+	// Tab bar for switching between detail sections
+	var tabs []string
+	for i, tab := range detailTabs {
+		if i == m.detailTab {
+			tabs = append(tabs, HighlightStyle.Render("["+tab+"]"))
+		} else {
+			tabs = append(tabs, StatusStyle.Render(" "+tab+" "))
+		}
+	}
+	b.WriteString(strings.Join(tabs, " ") + "\n\n")
+
+	switch detailTabs[m.detailTab] {
+	case "Git":
+		b.WriteString(m.viewGitTab(project) + "\n\n")
+	case "Scripts":
+		b.WriteString(m.viewScriptsTab(project) + "\n\n")
+	default:
+		b.WriteString(m.viewOverviewTab(project) + "\n\n")
+	}
+
+	if m.error != "" {
+		b.WriteString(ErrorStyle.Render("Error: "+m.error) + "\n")
+	}
+
+	help := m.helpView()
+	b.WriteString("\n" + help)
+
+	return BaseStyle.Render(b.String())
+}
+
+// viewOverviewTab renders the general project details
+func (m Model) viewOverviewTab(project scanner.UVProject) string {
+	var b strings.Builder
+
 	// Create a fancy header for the project details
 	detailsHeader := lipgloss.NewStyle().
 		Foreground(highlightColor).
@@ -747,22 +1284,63 @@ func (m Model) viewProjectDetail() string {
 	// Only show name, date created, python version, and size with proper spacing
 	infoRows = append(infoRows, InfoTitleStyle.Render("Name: ")+InfoValueStyle.Render(project.Name))
 	infoRows = append(infoRows, InfoTitleStyle.Render("Date Created: ")+InfoValueStyle.Render(project.LastModified.Format(time.RFC1123)))
+	infoRows = append(infoRows, InfoTitleStyle.Render("Managed By: ")+InfoValueStyle.Render(project.Tool.Label()))
 	infoRows = append(infoRows, InfoTitleStyle.Render("Python Version: ")+InfoValueStyle.Render(project.PythonVersion))
 	infoRows = append(infoRows, InfoTitleStyle.Render("Size: ")+InfoValueStyle.Render(scanner.FormatSize(project.Size)))
+	if project.IsWorkspaceRoot() {
+		var names []string
+		for _, member := range project.Members {
+			names = append(names, member.Name)
+		}
+		infoRows = append(infoRows, InfoTitleStyle.Render("Workspace Members: ")+InfoValueStyle.Render(strings.Join(names, ", ")))
+	} else if project.IsWorkspaceMember() {
+		infoRows = append(infoRows, InfoTitleStyle.Render("Workspace: ")+InfoValueStyle.Render(filepath.Base(project.Workspace)+" (shares its uv.lock and .venv)"))
+	}
+	if project.Drift != nil {
+		infoRows = append(infoRows, InfoTitleStyle.Render("Lock Drift: ")+WarningStyle.Render(project.Drift.Summary()))
+	}
+	if report := m.testResults.For(project.Path); report != nil {
+		infoRows = append(infoRows, InfoTitleStyle.Render("Last Test Run: ")+InfoValueStyle.Render(report.Summary()+", "+report.Finished.Format("2006-01-02 15:04")))
+	}
 
 	// Join rows with newlines to ensure vertical layout
 	infoContent := strings.Join(infoRows, "\n")
 	info := FancyBoxStyle.Render(infoContent) // Use fancy box style for details
-	b.WriteString(info + "\n\n")
+	b.WriteString(info)
 
-	if m.error != "" {
-		b.WriteString(ErrorStyle.Render("Error: "+m.error) + "\n")
+	return b.String()
+}
+
+// viewGitTab renders the project's git status
+func (m Model) viewGitTab(project scanner.UVProject) string {
+	git := project.Git
+	if git == nil {
+		return FancyBoxStyle.Render("This project is not a git repository.")
 	}
 
-	help := HelpStyle.Render("Esc: Back • q: Quit")
-	b.WriteString("\n" + help)
+	state := SuccessStyle.Render("clean")
+	if git.Dirty {
+		state = WarningStyle.Render("uncommitted changes")
+	}
 
-	return BaseStyle.Render(b.String())
+	upstream := "none"
+	if git.HasUpstream {
+		upstream = fmt.Sprintf("%s (↑%d ↓%d)", git.Upstream, git.Ahead, git.Behind)
+	}
+
+	lastCommit := "no commits yet"
+	if !git.LastCommit.IsZero() {
+		lastCommit = git.LastCommit.Format(time.RFC1123)
+	}
+
+	var infoRows []string
+	infoRows = append(infoRows, InfoTitleStyle.Render("Branch: ")+InfoValueStyle.Render(git.Branch))
+	infoRows = append(infoRows, InfoTitleStyle.Render("State: ")+InfoValueStyle.Render(state))
+	infoRows = append(infoRows, InfoTitleStyle.Render("Upstream: ")+InfoValueStyle.Render(upstream))
+	infoRows = append(infoRows, InfoTitleStyle.Render("Last Commit: ")+InfoValueStyle.Render(lastCommit))
+	infoRows = append(infoRows, InfoTitleStyle.Render("Author: ")+InfoValueStyle.Render(git.LastAuthor))
+
+	return FancyBoxStyle.Render(strings.Join(infoRows, "\n"))
 }
 
 // viewNewProject renders the new project creation screen
@@ -789,7 +1367,7 @@ func (m Model) viewNewProject() string {
 		b.WriteString(ErrorStyle.Render("Error: "+m.error) + "\n\n")
 	}
 
-	help := HelpStyle.Render("Enter: Create Project • Esc: Back • Ctrl+C: Quit")
+	help := m.helpView()
 	b.WriteString(help)
 
 	return BaseStyle.Render(b.String())
@@ -814,6 +1392,7 @@ func (m Model) viewLoading() string {
 // Custom message types
 type projectsFoundMsg struct {
 	projects []scanner.UVProject
+	status   string
 }
 
 type errMsg struct {
@@ -828,3 +1407,115 @@ type projectCreatedMsg struct {
 	projects    []scanner.UVProject
 	projectName string
 }
+
+type bulkStepMsg struct {
+	run     int
+	results []bulkResult
+}
+
+type testFinishedMsg struct {
+	project scanner.UVProject
+	report  *testrun.Report
+}
+
+type migrateDoneMsg struct {
+	project scanner.UVProject
+	output  string
+}
+
+type driftResolvedMsg struct {
+	project scanner.UVProject
+	name    string
+}
+
+type auditDoneMsg struct {
+	reports    []audit.Report
+	advisories int
+}
+
+type licensesCheckedMsg struct {
+	reports []licenses.Report
+}
+
+type exportDoneMsg struct {
+	written  []string
+	failures []string
+}
+
+type healthCheckedMsg struct {
+	findings []health.Finding
+	checked  int
+}
+
+type healthFixedMsg struct {
+	project scanner.UVProject
+	fix     string
+}
+
+type cleanScanMsg struct {
+	projects []string
+	found    map[string][]scanner.CleanTarget
+}
+
+type cleanDoneMsg struct {
+	projects []string
+	freed    int64
+	failures []string
+}
+
+type staleFoundMsg struct {
+	entries []staleEntry
+}
+
+type staleResolvedMsg struct {
+	path   string
+	status string
+}
+
+type staleArchivedMsg struct {
+	path   string
+	status string
+}
+
+type archivesFoundMsg struct {
+	entries []archive.Entry
+}
+
+type archiveRestoredMsg struct {
+	status string
+}
+
+type dirUsageMsg struct {
+	path    string
+	entries []scanner.UsageEntry
+}
+
+type packageUsageMsg struct {
+	project  string
+	packages []scanner.UsageEntry
+}
+
+type projectsChangedMsg struct {
+	watcher *watcher.Watcher
+	updated []scanner.UVProject
+	removed []string
+}
+
+type execFinishedMsg struct {
+	what string
+	err  error
+}
+
+type runOutputMsg struct {
+	process *runner.Process
+	lines   []string
+}
+
+type runFinishedMsg struct {
+	process *runner.Process
+	result  runner.Result
+}
+
+type jobEventMsg struct {
+	job jobs.Job
+}
@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/chloebubble/tuv/pkg/scanner"
+)
+
+// commandFromString splits a configured command line into a command with extra arguments appended
+func commandFromString(line string, args ...string) (*exec.Cmd, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil, errors.New("no command configured")
+	}
+	return exec.Command(fields[0], append(fields[1:], args...)...), nil
+}
+
+// openInEditor suspends the TUI and opens the project in the configured editor, $VISUAL or $EDITOR
+func (m Model) openInEditor(project scanner.UVProject) (tea.Model, tea.Cmd) {
+	editor := "vi"
+	for _, candidate := range []string{m.config.Editor, os.Getenv("VISUAL"), os.Getenv("EDITOR")} {
+		if candidate = strings.TrimSpace(candidate); candidate != "" {
+			editor = candidate
+			break
+		}
+	}
+
+	cmd, err := commandFromString(editor, project.Path)
+	if err != nil {
+		m.error = "Can't open the editor: " + err.Error()
+		return m, nil
+	}
+	cmd.Dir = project.Path
+	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return execFinishedMsg{what: "Editor", err: err}
+	})
+}
+
+// openShell suspends the TUI and starts a shell in the project directory with its venv activated
+func (m Model) openShell(project scanner.UVProject) (tea.Model, tea.Cmd) {
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = "/bin/sh"
+		if runtime.GOOS == "windows" {
+			shell = "cmd.exe"
+		}
+	}
+
+	cmd := exec.Command(shell)
+	cmd.Dir = project.Path
+	cmd.Env = os.Environ()
+
+	venv := filepath.Join(project.Path, ".venv")
+	if project.HasVenv {
+		bin := filepath.Join(venv, "bin")
+		if runtime.GOOS == "windows" {
+			bin = filepath.Join(venv, "Scripts")
+		}
+		cmd.Env = append(cmd.Env,
+			"VIRTUAL_ENV="+venv,
+			"PATH="+bin+string(os.PathListSeparator)+os.Getenv("PATH"),
+		)
+	}
+
+	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return execFinishedMsg{what: "Shell", err: err}
+	})
+}
+
+// openFileManager opens the project directory in the configured or platform file manager
+func (m Model) openFileManager(project scanner.UVProject) (tea.Model, tea.Cmd) {
+	opener := m.config.FileManager
+	if strings.TrimSpace(opener) == "" {
+		switch runtime.GOOS {
+		case "darwin":
+			opener = "open"
+		case "windows":
+			opener = "explorer"
+		default:
+			opener = "xdg-open"
+		}
+	}
+
+	cmd, err := commandFromString(opener, project.Path)
+	if err != nil {
+		m.error = "Can't open the file manager: " + err.Error()
+		return m, nil
+	}
+	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return execFinishedMsg{what: "File manager", err: err}
+	})
+}
+
+// handleExecFinished reports how an external program exited once the TUI resumes
+func (m Model) handleExecFinished(msg execFinishedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.error = fmt.Sprintf("%s failed: %v", msg.what, msg.err)
+		return m, nil
+	}
+	m.error = ""
+	m.statusMsg = fmt.Sprintf("%s closed", msg.what)
+	return m, nil
+}
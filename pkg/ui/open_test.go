@@ -0,0 +1,30 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCommandFromString(t *testing.T) {
+	tests := []struct {
+		line    string
+		want    []string
+		wantErr bool
+	}{
+		{line: "vi", want: []string{"vi", "/proj"}},
+		{line: "  code --wait ", want: []string{"code", "--wait", "/proj"}},
+		{line: "", wantErr: true},
+		{line: " \t ", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			cmd, err := commandFromString(tt.line, "/proj")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("commandFromString(%q) error = %v, want error %v", tt.line, err, tt.wantErr)
+			}
+			if err == nil && !slices.Equal(cmd.Args, tt.want) {
+				t.Errorf("commandFromString(%q) args = %q, want %q", tt.line, cmd.Args, tt.want)
+			}
+		})
+	}
+}
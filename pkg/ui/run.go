@@ -0,0 +1,234 @@
+package ui
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/chloebubble/tuv/pkg/runner"
+	"github.com/chloebubble/tuv/pkg/scanner"
+)
+
+// maxRunLines is how much output is kept for the run screen
+const maxRunLines = 1000
+
+// runState holds the script or task currently shown on the run screen
+type runState struct {
+	project  scanner.UVProject
+	runnable scanner.Runnable
+	process  *runner.Process
+	lines    []string
+	result   *runner.Result
+	// killed is set once the process has been asked to stop, after which the screen can be left
+	killed bool
+}
+
+// loadScripts reads the runnable scripts and tasks of the project being viewed
+func (m *Model) loadScripts(project scanner.UVProject) {
+	m.scripts = nil
+	m.scriptCursor = 0
+	if pyproject, err := scanner.ReadPyproject(project.Path); err == nil {
+		m.scripts = pyproject.Runnables()
+	}
+}
+
+// runArgs returns the uv command line for a script or task
+func runArgs(r scanner.Runnable) []string {
+	if !r.IsTask {
+		return []string{"run", r.Name}
+	}
+	if runtime.GOOS == "windows" {
+		return []string{"run", "--", "cmd", "/C", r.Command}
+	}
+	return []string{"run", "--", "sh", "-c", r.Command}
+}
+
+// startRun runs a script or task through uv run and shows its output as it arrives
+func (m Model) startRun(project scanner.UVProject, r scanner.Runnable) (tea.Model, tea.Cmd) {
+	process, err := runner.Start(project.Path, "uv", runArgs(r)...)
+	if err != nil {
+		m.error = fmt.Sprintf("Could not start %s: %v", r.Name, err)
+		return m, nil
+	}
+
+	m.run = runState{project: project, runnable: r, process: process}
+	m.state = StateRun
+	m.error = ""
+	return m, waitForRunOutput(process)
+}
+
+// waitForRunOutput waits for the next chunk of output, or the exit result once output ends
+func waitForRunOutput(p *runner.Process) tea.Cmd {
+	return func() tea.Msg {
+		line, ok := <-p.Lines()
+		if !ok {
+			return runFinishedMsg{process: p, result: <-p.Done()}
+		}
+
+		// Batch whatever else is already buffered to keep redraws down
+		lines := []string{line}
+		for len(lines) < 100 {
+			select {
+			case line, ok := <-p.Lines():
+				if !ok {
+					return runOutputMsg{process: p, lines: lines}
+				}
+				lines = append(lines, line)
+			default:
+				return runOutputMsg{process: p, lines: lines}
+			}
+		}
+		return runOutputMsg{process: p, lines: lines}
+	}
+}
+
+// handleRunOutput appends streamed output to the run screen
+func (m Model) handleRunOutput(msg runOutputMsg) (tea.Model, tea.Cmd) {
+	if msg.process != m.run.process {
+		return m, nil
+	}
+	m.run.lines = append(m.run.lines, msg.lines...)
+	if len(m.run.lines) > maxRunLines {
+		m.run.lines = m.run.lines[len(m.run.lines)-maxRunLines:]
+	}
+	return m, waitForRunOutput(msg.process)
+}
+
+// handleRunFinished records the finished run in the history
+func (m Model) handleRunFinished(msg runFinishedMsg) (tea.Model, tea.Cmd) {
+	if msg.process != m.run.process {
+		return m, nil
+	}
+	m.run.result = &msg.result
+	if msg.result.Err != nil {
+		m.error = msg.result.Err.Error()
+	}
+
+	record := runner.Record{
+		Name:     m.run.runnable.Name,
+		Command:  "uv " + strings.Join(runArgs(m.run.runnable), " "),
+		Started:  msg.process.Started(),
+		Duration: msg.result.Duration,
+		ExitCode: msg.result.ExitCode,
+	}
+	if err := m.runHistory.Add(m.run.project.Path, record); err != nil {
+		m.error = "Could not save run history: " + err.Error()
+	}
+	return m, nil
+}
+
+// updateRun handles updates in the run state
+func (m Model) updateRun(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keyMap.Stop):
+			if m.run.result == nil {
+				if err := m.run.process.Kill(); err != nil {
+					m.error = "Could not stop process: " + err.Error()
+				} else {
+					m.run.killed = true
+				}
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Back):
+			if m.run.result == nil && !m.run.killed {
+				return m, nil
+			}
+			m.state = StateProjectDetail
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
+
+// viewRun renders the live output of a running script or task
+func (m Model) viewRun() string {
+	var b strings.Builder
+
+	title := TitleStyle.Render(fmt.Sprintf("Run: %s", m.run.runnable.Name))
+	b.WriteString(title + "\n")
+	b.WriteString(StatusStyle.Render("uv "+strings.Join(runArgs(m.run.runnable), " ")) + "\n\n")
+
+	// Show the tail of the output that fits on screen
+	height := 20
+	if m.height > 0 {
+		height = max(m.height-12, 5)
+	}
+	lines := m.run.lines
+	if len(lines) > height {
+		lines = lines[len(lines)-height:]
+	}
+	output := strings.Join(lines, "\n")
+	if output == "" {
+		output = StatusStyle.Render("(no output yet)")
+	}
+	b.WriteString(lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(secondaryColor).
+		Padding(0, 1).
+		Render(output) + "\n\n")
+
+	if result := m.run.result; result == nil {
+		elapsed := time.Since(m.run.process.Started()).Round(time.Second)
+		b.WriteString(fmt.Sprintf("%s Running for %s", m.spinner.View(), elapsed) + "\n\n")
+	} else if result.ExitCode == 0 {
+		b.WriteString(SuccessStyle.Render(fmt.Sprintf("✓ Exited with code 0 in %s", result.Duration.Round(time.Millisecond))) + "\n\n")
+	} else {
+		b.WriteString(ErrorStyle.Render(fmt.Sprintf("✗ Exited with code %d in %s", result.ExitCode, result.Duration.Round(time.Millisecond))) + "\n\n")
+	}
+
+	if m.error != "" {
+		b.WriteString(ErrorStyle.Render("Error: "+m.error) + "\n\n")
+	}
+
+	help := m.helpView()
+	b.WriteString(help)
+
+	return BaseStyle.Render(b.String())
+}
+
+// viewScriptsTab renders the project's scripts and tasks with its recent runs
+func (m Model) viewScriptsTab(project scanner.UVProject) string {
+	var b strings.Builder
+
+	if len(m.scripts) == 0 {
+		b.WriteString(FancyBoxStyle.Render("No [project.scripts] or [tool.tuv.tasks] in pyproject.toml."))
+	} else {
+		var rows []string
+		for i, r := range m.scripts {
+			kind := "script"
+			if r.IsTask {
+				kind = "task"
+			}
+			row := fmt.Sprintf("%-20s %-6s %s", truncate(r.Name, 20), kind, truncate(r.Command, 26))
+			if i == m.scriptCursor {
+				rows = append(rows, SelectedProjectStyle.Render(" > "+row))
+			} else {
+				rows = append(rows, ProjectStyle.Render("   "+row))
+			}
+		}
+		b.WriteString(ProjectListStyle.Render(strings.Join(rows, "\n")))
+	}
+
+	history := m.runHistory.For(project.Path)
+	if len(history) > 0 {
+		b.WriteString("\n\n" + InputLabelStyle.Render("Recent runs") + "\n")
+		for _, record := range history[:min(len(history), 5)] {
+			status := SuccessStyle.Render("✓")
+			if record.ExitCode != 0 {
+				status = ErrorStyle.Render(fmt.Sprintf("✗ %d", record.ExitCode))
+			}
+			b.WriteString(fmt.Sprintf("%s %-20s %8s  %s\n", status, truncate(record.Name, 20),
+				record.Duration.Round(time.Millisecond), StatusStyle.Render(record.Started.Format("2006-01-02 15:04"))))
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
@@ -0,0 +1,450 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/chloebubble/tuv/pkg/config"
+	"github.com/chloebubble/tuv/pkg/scanner"
+)
+
+// settingKind is how a setting is edited on the settings screen
+type settingKind int
+
+const (
+	settingText settingKind = iota
+	settingBool
+	settingScreen
+)
+
+// setting is one editable config field, named by its config key. set parses and validates a new value into a draft config.
+type setting struct {
+	key   string
+	label string
+	help  string
+	kind  settingKind
+	get   func(c *config.Config) string
+	set   func(c *config.Config, value string) error
+	open  func(m Model) (tea.Model, tea.Cmd)
+}
+
+// settings lists every config field in the order the settings screen shows them
+var settings = []setting{
+	{
+		key:   "parent_directory",
+		label: "Projects directory",
+		help:  "Directory scanned for uv projects. It must already exist.",
+		get:   func(c *config.Config) string { return c.ParentDirectory },
+		set: func(c *config.Config, value string) error {
+			path, err := existingDir(value)
+			if err != nil {
+				return err
+			}
+			c.ParentDirectory = path
+			return nil
+		},
+	},
+	{
+		key:   "stale_after_days",
+		label: "Stale after (days)",
+		help:  "Idle period before a project shows up in the stale report.",
+		get:   func(c *config.Config) string { return strconv.Itoa(c.StaleAfterDays) },
+		set: func(c *config.Config, value string) error {
+			n, err := positiveInt(value)
+			c.StaleAfterDays = n
+			return err
+		},
+	},
+	{
+		key:   "dismissed_stale",
+		label: "Dismissed stale",
+		help:  "Projects hidden from the stale report, separated by commas.",
+		get:   func(c *config.Config) string { return strings.Join(c.DismissedStale, ", ") },
+		set: func(c *config.Config, value string) error {
+			c.DismissedStale = config.SplitList(value)
+			return nil
+		},
+	},
+	{
+		key:   "archive_directory",
+		label: "Archive directory",
+		help:  "Where archived projects are stored. It is created on first use.",
+		get:   func(c *config.Config) string { return c.ArchiveDirectory },
+		set: func(c *config.Config, value string) error {
+			path, err := expandHome(strings.TrimSpace(value))
+			if err != nil {
+				return err
+			}
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return fmt.Errorf("%s is not a directory", path)
+			}
+			c.ArchiveDirectory = path
+			return nil
+		},
+	},
+	{
+		key:   "archive_exclude",
+		label: "Archive excludes",
+		help:  "Directories left out of archives, separated by commas.",
+		get:   func(c *config.Config) string { return strings.Join(c.ArchiveExclude, ", ") },
+		set: func(c *config.Config, value string) error {
+			c.ArchiveExclude = config.SplitList(value)
+			return nil
+		},
+	},
+	{
+		key:   "watch",
+		label: "Watch for changes",
+		help:  "Refresh the project list when files change on disk.",
+		kind:  settingBool,
+		get:   func(c *config.Config) string { return strconv.FormatBool(c.Watch) },
+		set: func(c *config.Config, value string) error {
+			watch, err := strconv.ParseBool(value)
+			c.Watch = watch
+			return err
+		},
+	},
+	{
+		key:   "editor",
+		label: "Editor",
+		help:  "Command used to open projects. Empty uses $VISUAL, then $EDITOR.",
+		get:   func(c *config.Config) string { return c.Editor },
+		set: func(c *config.Config, value string) error {
+			c.Editor = strings.TrimSpace(value)
+			return commandExists(c.Editor)
+		},
+	},
+	{
+		key:   "file_manager",
+		label: "File manager",
+		help:  "Command used to show projects. Empty uses open, explorer or xdg-open.",
+		get:   func(c *config.Config) string { return c.FileManager },
+		set: func(c *config.Config, value string) error {
+			c.FileManager = strings.TrimSpace(value)
+			return commandExists(c.FileManager)
+		},
+	},
+	{
+		key:   "max_jobs",
+		label: "Parallel jobs",
+		help:  "How many background jobs may run at the same time.",
+		get:   func(c *config.Config) string { return strconv.Itoa(c.MaxJobs) },
+		set: func(c *config.Config, value string) error {
+			n, err := positiveInt(value)
+			c.MaxJobs = n
+			return err
+		},
+	},
+	{
+		key:   "advisory_directory",
+		label: "Advisory database",
+		help:  "Directory holding OSV advisories for the audit, as .json files or OSV's PyPI all.zip.",
+		get:   func(c *config.Config) string { return c.AdvisoryDirectory },
+		set: func(c *config.Config, value string) error {
+			path, err := expandHome(strings.TrimSpace(value))
+			if err != nil {
+				return err
+			}
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return fmt.Errorf("%s is not a directory", path)
+			}
+			c.AdvisoryDirectory = path
+			return nil
+		},
+	},
+	{
+		key:   "license_deny",
+		label: "Denied licenses",
+		help:  "SPDX identifiers or globs flagged in the license report, separated by commas, e.g. AGPL-*, SSPL-1.0",
+		get:   func(c *config.Config) string { return strings.Join(c.LicenseDeny, ", ") },
+		set: func(c *config.Config, value string) error {
+			c.LicenseDeny = config.SplitList(value)
+			for _, pattern := range c.LicenseDeny {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return fmt.Errorf("%q is not a valid glob", pattern)
+				}
+			}
+			return nil
+		},
+	},
+	{
+		key:   "theme",
+		label: "Theme",
+		help:  "Colors used by the interface.",
+		kind:  settingScreen,
+		get:   func(c *config.Config) string { return c.Theme },
+		open:  Model.openThemes,
+	},
+	{
+		key:   "keys",
+		label: "Key overrides",
+		help:  "Keybinding overrides as action=keys, separated by commas, e.g. scan=s r, quit=ctrl+c",
+		get:   func(c *config.Config) string { return config.FormatKeys(c.Keys) },
+		set: func(c *config.Config, value string) error {
+			keys, err := config.ParseKeys(value)
+			c.Keys = keys
+			return err
+		},
+	},
+}
+
+// settingsState holds the settings screen's cursor and the field being edited
+type settingsState struct {
+	cursor int
+	input  textinput.Model
+	back   AppState
+}
+
+// openSettings shows the settings screen
+func (m Model) openSettings() (tea.Model, tea.Cmd) {
+	input := textinput.New()
+	input.CharLimit = 1024
+	input.Width = 56
+	m.settings = settingsState{input: input, back: m.state}
+	m.state = StateSettings
+	m.error = ""
+	return m, nil
+}
+
+// updateSettings handles updates in the settings list state
+func (m Model) updateSettings(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		current := settings[m.settings.cursor]
+
+		switch {
+		case key.Matches(msg, m.keyMap.Up):
+			if m.settings.cursor > 0 {
+				m.settings.cursor--
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Down):
+			if m.settings.cursor < len(settings)-1 {
+				m.settings.cursor++
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Toggle) && current.kind == settingBool,
+			key.Matches(msg, m.keyMap.Select) && current.kind == settingBool:
+			value, _ := strconv.ParseBool(current.get(m.config))
+			return m.commitSetting(current, strconv.FormatBool(!value))
+
+		case key.Matches(msg, m.keyMap.Select) && current.kind == settingScreen:
+			return current.open(m)
+
+		case key.Matches(msg, m.keyMap.Select):
+			m.error = ""
+			m.settings.input.SetValue(current.get(m.config))
+			m.settings.input.CursorEnd()
+			m.settings.input.Focus()
+			m.state = StateSettingsEdit
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Back):
+			m.state = m.settings.back
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
+
+// updateSettingsEdit handles updates while a setting's value is being typed
+func (m Model) updateSettingsEdit(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, m.keyMap.Select):
+			m.settings.input.Blur()
+			updated, cmd := m.commitSetting(settings[m.settings.cursor], m.settings.input.Value())
+			if um := updated.(Model); um.error == "" {
+				um.state = StateSettings
+				return um, cmd
+			}
+			return updated, cmd
+
+		case key.Matches(msg, m.keyMap.Back):
+			m.settings.input.Blur()
+			m.error = ""
+			m.state = StateSettings
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.settings.input, cmd = m.settings.input.Update(msg)
+	return m, cmd
+}
+
+// commitSetting validates a new value against a copy of the config, then saves and applies it
+func (m Model) commitSetting(s setting, value string) (tea.Model, tea.Cmd) {
+	draft := *m.config
+	err := s.set(&draft, value)
+	if err == nil {
+		err = draft.Validate()
+	}
+	if err == nil {
+		// Keys can only be checked against each other once the whole map is known
+		_, err = NewKeyMap(draft.Keys)
+	}
+	if err != nil {
+		m.error = fmt.Sprintf("%s: %v", s.label, err)
+		m.settings.input.Focus()
+		return m, nil
+	}
+
+	previous := *m.config
+	*m.config = draft
+	if err := m.config.Save(); err != nil {
+		*m.config = previous
+		m.error = "Could not save configuration: " + err.Error()
+		return m, nil
+	}
+
+	m.error = ""
+	m.statusMsg = fmt.Sprintf("Saved %s", strings.ToLower(s.label))
+	return m, m.applyConfig(previous)
+}
+
+// applyConfig brings the running app in line with a changed config, so nothing needs a restart
+func (m *Model) applyConfig(previous config.Config) tea.Cmd {
+	var cmd tea.Cmd
+	if m.config.ParentDirectory != previous.ParentDirectory {
+		m.scanner = scanner.NewScanner(m.config.ParentDirectory)
+		m.selected = make(map[string]bool)
+		m.selectedProject = 0
+		m.scanProjects("")
+		cmd = m.startWatcher()
+	} else if m.config.Watch != previous.Watch {
+		cmd = m.startWatcher()
+	}
+
+	if m.config.MaxJobs != previous.MaxJobs {
+		m.jobs.SetParallelism(m.config.MaxJobs)
+	}
+	if !maps.EqualFunc(m.config.Keys, previous.Keys, slices.Equal) {
+		if keyMap, err := NewKeyMap(m.config.Keys); err == nil {
+			m.keyMap = keyMap
+		}
+	}
+	if m.config.Theme != previous.Theme {
+		if err := m.loadTheme(); err != nil {
+			m.error = "Could not load theme: " + err.Error()
+		}
+	}
+	return cmd
+}
+
+// viewSettings renders the settings list, or the editor for the highlighted setting
+func (m Model) viewSettings() string {
+	var b strings.Builder
+
+	title := TitleStyle.Render("Settings")
+	b.WriteString(title + "\n")
+
+	divider := lipgloss.NewStyle().
+		Foreground(secondaryColor).
+		Render("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	b.WriteString(divider + "\n\n")
+
+	var rows []string
+	for i, s := range settings {
+		value := s.get(m.config)
+		if value == "" {
+			value = "(not set)"
+		}
+		row := fmt.Sprintf("%-20s %s", s.label, truncate(value, 34))
+		if i == m.settings.cursor {
+			rows = append(rows, SelectedProjectStyle.Render(" > "+row))
+		} else {
+			rows = append(rows, ProjectStyle.Render("   "+row))
+		}
+	}
+	b.WriteString(ProjectListStyle.Render(strings.Join(rows, "\n")) + "\n\n")
+
+	current := settings[m.settings.cursor]
+	if m.state == StateSettingsEdit {
+		input := InputStyle.Render(InputLabelStyle.Render(current.label+": ") + "\n" + m.settings.input.View())
+		b.WriteString(input + "\n")
+	}
+	b.WriteString(StatusStyle.Render(current.help) + "\n")
+	if name, ok := m.config.EnvOverride(current.key); ok {
+		b.WriteString(WarningStyle.Render("Set by "+name+", which overrides the config file on every start.") + "\n")
+	}
+	b.WriteString("\n")
+
+	if m.error != "" {
+		b.WriteString(ErrorStyle.Render("Error: "+m.error) + "\n\n")
+	} else if m.statusMsg != "" {
+		b.WriteString(StatusStyle.Render(m.statusMsg) + "\n\n")
+	}
+
+	help := m.helpView()
+	b.WriteString(help)
+
+	return BaseStyle.Render(b.String())
+}
+
+// expandHome replaces a leading ~ with the user's home directory
+func expandHome(path string) (string, error) {
+	if !strings.HasPrefix(path, "~") {
+		return path, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, path[1:]), nil
+}
+
+// existingDir expands and checks a path that must be an existing directory
+func existingDir(value string) (string, error) {
+	path, err := expandHome(strings.TrimSpace(value))
+	if err != nil {
+		return "", err
+	}
+	if path == "" {
+		return "", errors.New("a directory is required")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("%s does not exist", path)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("%s is not a directory", path)
+	}
+	return filepath.Abs(path)
+}
+
+// positiveInt parses a whole number of at least one
+func positiveInt(value string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%q is not a whole number of at least 1", value)
+	}
+	return n, nil
+}
+
+// commandExists checks that the program of a command line can be found, allowing an empty command
+func commandExists(command string) error {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return nil
+	}
+	if _, err := exec.LookPath(fields[0]); err != nil {
+		return fmt.Errorf("%s was not found", fields[0])
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/chloebubble/tuv/pkg/jobs"
+	"github.com/chloebubble/tuv/pkg/scanner"
+)
+
+// Stale report actions that change a project and are confirmed before they run
+const (
+	staleDeleteVenv = "delete venv"
+	staleArchive    = "archive"
+)
+
+// staleEntry is a project that has not been worked on within the configured period
+type staleEntry struct {
+	project      scanner.UVProject
+	lastActivity time.Time
+	reclaimable  int64
+}
+
+// openStale computes the stale project report and shows it
+func (m Model) openStale() (tea.Model, tea.Cmd) {
+	m.state = StateStale
+	m.staleCursor = 0
+	m.error = ""
+	m.stalePending = true
+
+	projects := m.projects
+	days := m.config.StaleAfterDays
+	dismissed := m.config.DismissedStale
+
+	id := m.startJob("Find stale projects", func(ctx context.Context, report jobs.Reporter) (tea.Msg, error) {
+		cutoff := time.Now().AddDate(0, 0, -days)
+		var entries []staleEntry
+		for i, project := range projects {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			report(float64(i)/float64(len(projects)), project.Name)
+			if slices.Contains(dismissed, project.Path) {
+				continue
+			}
+
+			lastActivity := scanner.LastActivity(project.Path)
+			if lastActivity.After(cutoff) {
+				continue
+			}
+
+			var reclaimable int64
+			if targets, err := scanner.FindReclaimable(project.Path); err == nil {
+				for _, target := range targets {
+					reclaimable += target.Size
+				}
+			}
+
+			entries = append(entries, staleEntry{
+				project:      project,
+				lastActivity: lastActivity,
+				reclaimable:  reclaimable,
+			})
+		}
+
+		// Biggest wins first
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].reclaimable > entries[j].reclaimable
+		})
+
+		return staleFoundMsg{entries}, nil
+	})
+	m.awaitJob(StateStale, id)
+	return m, nil
+}
+
+// updateStale handles updates in the stale projects state
+func (m Model) updateStale(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.stalePending && !key.Matches(msg, m.keyMap.Back) {
+			return m, nil
+		}
+		if m.staleConfirm != "" {
+			switch {
+			case key.Matches(msg, m.keyMap.Confirm):
+				return m.runStaleAction()
+			case key.Matches(msg, m.keyMap.Cancel), key.Matches(msg, m.keyMap.Back):
+				m.staleConfirm = ""
+			}
+			return m, nil
+		}
+
+		switch {
+		case key.Matches(msg, m.keyMap.Up):
+			if m.staleCursor > 0 {
+				m.staleCursor--
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Down):
+			if m.staleCursor < len(m.stale)-1 {
+				m.staleCursor++
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.DeleteVenv):
+			if len(m.stale) == 0 {
+				return m, nil
+			}
+			entry := m.stale[m.staleCursor]
+			if !entry.project.HasVenv {
+				m.statusMsg = fmt.Sprintf("%s has no virtual environment", entry.project.Name)
+				return m, nil
+			}
+			m.staleConfirm = staleDeleteVenv
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Archive):
+			if len(m.stale) == 0 {
+				return m, nil
+			}
+			m.staleConfirm = staleArchive
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Dismiss):
+			if len(m.stale) == 0 {
+				return m, nil
+			}
+			entry := m.stale[m.staleCursor]
+			m.config.DismissedStale = append(m.config.DismissedStale, entry.project.Path)
+			if err := m.config.Save(); err != nil {
+				m.error = "Could not save configuration: " + err.Error()
+				return m, nil
+			}
+			m.removeStale(entry.project.Path)
+			m.statusMsg = fmt.Sprintf("Dismissed %s", entry.project.Name)
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Back):
+			m.state = StateMainMenu
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
+
+// runStaleAction starts the confirmed action on the highlighted stale project
+func (m Model) runStaleAction() (tea.Model, tea.Cmd) {
+	action := m.staleConfirm
+	m.staleConfirm = ""
+	if len(m.stale) == 0 {
+		return m, nil
+	}
+	entry := m.stale[m.staleCursor]
+
+	switch action {
+	case staleDeleteVenv:
+		m.startJob("Delete venv of "+entry.project.Name, func(ctx context.Context, report jobs.Reporter) (tea.Msg, error) {
+			if err := scanner.DeleteVenv(entry.project.Path); err != nil {
+				return nil, err
+			}
+			return staleResolvedMsg{path: entry.project.Path, status: fmt.Sprintf("Deleted venv of %s", entry.project.Name)}, nil
+		})
+	case staleArchive:
+		m.startJob("Archive "+entry.project.Name, func(ctx context.Context, report jobs.Reporter) (tea.Msg, error) {
+			status, err := m.archiveProject(entry.project)
+			if err != nil {
+				return nil, err
+			}
+			return staleArchivedMsg{path: entry.project.Path, status: status}, nil
+		})
+	}
+	return m, nil
+}
+
+// removeStale drops a project from the stale report
+func (m *Model) removeStale(path string) {
+	m.stale = slices.DeleteFunc(slices.Clone(m.stale), func(e staleEntry) bool {
+		return e.project.Path == path
+	})
+	if m.staleCursor >= len(m.stale) {
+		m.staleCursor = max(len(m.stale)-1, 0)
+	}
+}
+
+// viewStale renders the stale projects report
+func (m Model) viewStale() string {
+	var b strings.Builder
+
+	b.WriteString(GetCompactLogo() + "\n")
+
+	title := TitleStyle.Render("Stale Projects")
+	b.WriteString(title + "\n")
+
+	divider := lipgloss.NewStyle().
+		Foreground(secondaryColor).
+		Render("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	b.WriteString(divider + "\n\n")
+
+	if m.stalePending {
+		b.WriteString(FancyBoxStyle.Render(fmt.Sprintf("%s Looking for stale projects...", m.spinner.View())) + "\n\n")
+	} else if len(m.stale) == 0 {
+		emptyMsg := fmt.Sprintf("No projects have been idle for more than %d days.", m.config.StaleAfterDays)
+		b.WriteString(FancyBoxStyle.Render(emptyMsg) + "\n\n")
+	} else {
+		var total int64
+		for _, entry := range m.stale {
+			total += entry.reclaimable
+		}
+		countMsg := fmt.Sprintf("%s projects idle for over %d days • %s reclaimable",
+			HighlightStyle.Render(fmt.Sprintf("%d", len(m.stale))),
+			m.config.StaleAfterDays,
+			HighlightStyle.Render(scanner.FormatSize(total)))
+		b.WriteString(countMsg + "\n\n")
+
+		var rows []string
+		for i, entry := range m.stale {
+			idle := int(time.Since(entry.lastActivity).Hours() / 24)
+			row := fmt.Sprintf("%-24s %5dd idle %10s", truncate(entry.project.Name, 24), idle, scanner.FormatSize(entry.reclaimable))
+			if i == m.staleCursor {
+				rows = append(rows, SelectedProjectStyle.Render(" > "+row))
+			} else {
+				rows = append(rows, ProjectStyle.Render("   "+row))
+			}
+		}
+		b.WriteString(ProjectListStyle.Render(strings.Join(rows, "\n")) + "\n\n")
+
+		if m.staleConfirm != "" {
+			project := m.stale[m.staleCursor].project
+			var warning string
+			switch m.staleConfirm {
+			case staleDeleteVenv:
+				warning = fmt.Sprintf("Delete the virtual environment of %s? uv sync can recreate it.", project.Name)
+			case staleArchive:
+				excluded := strings.Join(m.config.ArchiveExclude, ", ")
+				warning = fmt.Sprintf("Archive %s and delete it from %s? Excluded directories (%s) are not kept.", project.Name, m.config.ParentDirectory, excluded)
+			}
+			b.WriteString(WarningStyle.Render(warning) + "\n\n")
+		}
+	}
+
+	if !m.stalePending && m.statusMsg != "" {
+		b.WriteString(StatusStyle.Render(m.statusMsg) + "\n")
+	}
+
+	if m.error != "" {
+		b.WriteString(ErrorStyle.Render("Error: "+m.error) + "\n")
+	}
+
+	help := m.helpView()
+	b.WriteString("\n" + help)
+
+	return BaseStyle.Render(b.String())
+}
+
+// truncate shortens s to at most n runes, marking the cut with an ellipsis
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
@@ -2,141 +2,183 @@ package ui
 
 import (
 	"github.com/charmbracelet/lipgloss"
+	"github.com/chloebubble/tuv/pkg/theme"
 )
 
 var (
-	// Colors
-	primaryColor   = lipgloss.Color("#7D56F4")
-	secondaryColor = lipgloss.Color("#AE88FD")
-	accentColor    = lipgloss.Color("#43BF6D")
-	warningColor   = lipgloss.Color("#F2B705")
-	errorColor     = lipgloss.Color("#F25757")
-	textColor      = lipgloss.Color("#FFFFFF")
-	dimTextColor   = lipgloss.Color("#CCCCCC")
-	bgColor        = lipgloss.Color("#1A1B26")
-	highlightColor = lipgloss.Color("#FF79C6") // New color for highlights
+	// Colors, set from the active theme
+	primaryColor   lipgloss.Color
+	secondaryColor lipgloss.Color
+	accentColor    lipgloss.Color
+	warningColor   lipgloss.Color
+	errorColor     lipgloss.Color
+	textColor      lipgloss.Color
+	dimTextColor   lipgloss.Color
+	bgColor        lipgloss.Color
+	highlightColor lipgloss.Color
+
+	// Styles, rebuilt whenever the theme changes
+	BaseStyle            lipgloss.Style
+	TitleStyle           lipgloss.Style
+	SubtitleStyle        lipgloss.Style
+	MenuStyle            lipgloss.Style
+	SelectedItemStyle    lipgloss.Style
+	ItemStyle            lipgloss.Style
+	ProjectListStyle     lipgloss.Style
+	SelectedProjectStyle lipgloss.Style
+	ProjectStyle         lipgloss.Style
+	InfoStyle            lipgloss.Style
+	InfoTitleStyle       lipgloss.Style
+	InfoValueStyle       lipgloss.Style
+	StatusStyle          lipgloss.Style
+	SuccessStyle         lipgloss.Style
+	WarningStyle         lipgloss.Style
+	ErrorStyle           lipgloss.Style
+	InputStyle           lipgloss.Style
+	InputLabelStyle      lipgloss.Style
+	HelpStyle            lipgloss.Style
+	FancyBoxStyle        lipgloss.Style
+	HighlightStyle       lipgloss.Style
+	VersionBadgeStyle    lipgloss.Style
+)
+
+func init() {
+	ApplyTheme(theme.Dark)
+}
+
+// ApplyTheme sets the colors from a theme and rebuilds every style with them
+func ApplyTheme(t theme.Theme) {
+	primaryColor = lipgloss.Color(t.Primary)
+	secondaryColor = lipgloss.Color(t.Secondary)
+	accentColor = lipgloss.Color(t.Accent)
+	warningColor = lipgloss.Color(t.Warning)
+	errorColor = lipgloss.Color(t.Error)
+	textColor = lipgloss.Color(t.Text)
+	dimTextColor = lipgloss.Color(t.DimText)
+	bgColor = lipgloss.Color(t.Background)
+	highlightColor = lipgloss.Color(t.Highlight)
 
 	// Base styles
 	BaseStyle = lipgloss.NewStyle().
-			Background(bgColor).
-			Foreground(textColor)
+		Background(bgColor).
+		Foreground(textColor)
 
 	// Title styles
 	TitleStyle = lipgloss.NewStyle().
-			Foreground(primaryColor).
-			Bold(true).
-			MarginBottom(1).
-			PaddingLeft(2).
-			PaddingRight(2)
+		Foreground(primaryColor).
+		Bold(true).
+		MarginBottom(1).
+		PaddingLeft(2).
+		PaddingRight(2)
 
 	// Subtitle style
 	SubtitleStyle = lipgloss.NewStyle().
-			Foreground(secondaryColor).
-			Italic(true).
-			MarginLeft(1).
-			AlignVertical(lipgloss.Center)
+		Foreground(secondaryColor).
+		Italic(true).
+		MarginLeft(1).
+		AlignVertical(lipgloss.Center)
 
 	// Menu styles
 	MenuStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(secondaryColor).
-			Padding(1).
-			MarginRight(2).
-			Width(40)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(secondaryColor).
+		Padding(1).
+		MarginRight(2).
+		Width(40)
 
 	SelectedItemStyle = lipgloss.NewStyle().
-				Foreground(textColor).
-				Background(primaryColor).
-				Bold(true).
-				Padding(0, 1).
-				Width(38)
+		Foreground(textColor).
+		Background(primaryColor).
+		Bold(true).
+		Padding(0, 1).
+		Width(38)
 
 	ItemStyle = lipgloss.NewStyle().
-			Foreground(dimTextColor).
-			Width(38)
+		Foreground(dimTextColor).
+		Width(38)
 
 	// Project list styles
 	ProjectListStyle = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(secondaryColor).
-				Padding(1).
-				Width(60)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(secondaryColor).
+		Padding(1).
+		Width(60)
 
 	SelectedProjectStyle = lipgloss.NewStyle().
-				Foreground(textColor).
-				Background(primaryColor).
-				Bold(true).
-				Padding(0, 1).
-				Width(58)
+		Foreground(textColor).
+		Background(primaryColor).
+		Bold(true).
+		Padding(0, 1).
+		Width(58)
 
 	ProjectStyle = lipgloss.NewStyle().
-			Foreground(dimTextColor).
-			Width(58)
+		Foreground(dimTextColor).
+		Width(58)
 
 	// Info styles
 	InfoStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(secondaryColor).
-			Padding(1).
-			Width(60)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(secondaryColor).
+		Padding(1).
+		Width(60)
 
 	InfoTitleStyle = lipgloss.NewStyle().
-			Foreground(primaryColor).
-			Bold(true).
-			Width(16)
+		Foreground(primaryColor).
+		Bold(true).
+		Width(16)
 
 	InfoValueStyle = lipgloss.NewStyle().
-			Foreground(textColor).
-			Width(42)
+		Foreground(textColor).
+		Width(42)
 
 	// Status styles
 	StatusStyle = lipgloss.NewStyle().
-			Foreground(dimTextColor).
-			Italic(true)
+		Foreground(dimTextColor).
+		Italic(true)
 
 	SuccessStyle = lipgloss.NewStyle().
-			Foreground(accentColor).
-			Bold(true)
+		Foreground(accentColor).
+		Bold(true)
 
 	WarningStyle = lipgloss.NewStyle().
-			Foreground(warningColor).
-			Bold(true)
+		Foreground(warningColor).
+		Bold(true)
 
 	ErrorStyle = lipgloss.NewStyle().
-			Foreground(errorColor).
-			Bold(true)
+		Foreground(errorColor).
+		Bold(true)
 
 	// Input styles
 	InputStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(secondaryColor).
-			Padding(1)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(secondaryColor).
+		Padding(1)
 
 	InputLabelStyle = lipgloss.NewStyle().
-			Foreground(primaryColor).
-			Bold(true)
+		Foreground(primaryColor).
+		Bold(true)
 
 	// Help styles
 	HelpStyle = lipgloss.NewStyle().
-			Foreground(dimTextColor).
-			Italic(true)
+		Foreground(dimTextColor).
+		Italic(true)
 
 	// Fancy box styles with double borders
 	FancyBoxStyle = lipgloss.NewStyle().
-			Border(lipgloss.DoubleBorder()).
-			BorderForeground(highlightColor).
-			Padding(1).
-			Width(60)
+		Border(lipgloss.DoubleBorder()).
+		BorderForeground(highlightColor).
+		Padding(1).
+		Width(60)
 
 	// Highlight text style
 	HighlightStyle = lipgloss.NewStyle().
-			Foreground(highlightColor).
-			Bold(true)
+		Foreground(highlightColor).
+		Bold(true)
 
 	// Version badge style
 	VersionBadgeStyle = lipgloss.NewStyle().
-				Foreground(textColor).
-				Background(secondaryColor).
-				Padding(0, 1).
-				AlignVertical(lipgloss.Center)
-)
+		Foreground(textColor).
+		Background(secondaryColor).
+		Padding(0, 1).
+		AlignVertical(lipgloss.Center)
+}
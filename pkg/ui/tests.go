@@ -0,0 +1,215 @@
+package ui
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/chloebubble/tuv/pkg/jobs"
+	"github.com/chloebubble/tuv/pkg/scanner"
+	"github.com/chloebubble/tuv/pkg/testrun"
+)
+
+// maxTracebackLines is how much of an expanded traceback the test screen shows
+const maxTracebackLines = 20
+
+// testState holds the test screen's project, result and expanded failures
+type testState struct {
+	project  scanner.UVProject
+	report   *testrun.Report
+	pending  bool
+	cursor   int
+	expanded map[int]bool
+	back     AppState
+}
+
+// runTests runs a project's pytest suite in the background and shows the test screen
+func (m Model) runTests(project scanner.UVProject) (tea.Model, tea.Cmd) {
+	back := m.state
+	if back == StateTests {
+		back = m.tests.back
+	}
+	m.tests = testState{project: project, pending: true, expanded: make(map[int]bool), back: back}
+	m.state = StateTests
+	m.error = ""
+
+	id := m.startJob("Test "+project.Name, func(ctx context.Context, report jobs.Reporter) (tea.Msg, error) {
+		result, output, err := testrun.Run(ctx, project.Path)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			return nil, errors.New(failureSummary(err.Error(), output))
+		}
+		return testFinishedMsg{project: project, report: result}, nil
+	})
+	m.awaitJob(StateTests, id)
+	return m, nil
+}
+
+// handleTestFinished stores a finished test run and shows it if its project is still on screen
+func (m Model) handleTestFinished(msg testFinishedMsg) (tea.Model, tea.Cmd) {
+	if err := m.testResults.Set(msg.project.Path, msg.report); err != nil {
+		m.error = "Could not save test results: " + err.Error()
+	}
+	if msg.project.Path == m.tests.project.Path {
+		m.tests.report = msg.report
+		m.tests.pending = false
+		m.tests.cursor = 0
+		m.tests.expanded = make(map[int]bool)
+	}
+	return m, nil
+}
+
+// updateTests handles updates in the test results state
+func (m Model) updateTests(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		var problems []testrun.Case
+		if m.tests.report != nil {
+			problems = m.tests.report.Problems()
+		}
+
+		switch {
+		case key.Matches(msg, m.keyMap.Up):
+			if m.tests.cursor > 0 {
+				m.tests.cursor--
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Down):
+			if m.tests.cursor < len(problems)-1 {
+				m.tests.cursor++
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Select), key.Matches(msg, m.keyMap.Toggle):
+			if len(problems) > 0 {
+				m.tests.expanded[m.tests.cursor] = !m.tests.expanded[m.tests.cursor]
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Test):
+			if m.tests.pending {
+				return m, nil
+			}
+			return m.runTests(m.tests.project)
+
+		case key.Matches(msg, m.keyMap.Back):
+			m.state = m.tests.back
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
+
+// testBadge renders the short pass/fail marker shown next to a project in the list
+func testBadge(report *testrun.Report) string {
+	if report.OK() {
+		return SuccessStyle.Render(fmt.Sprintf("✓ %d", report.Passed))
+	}
+	return ErrorStyle.Render(fmt.Sprintf("✗ %d/%d", report.Failed+report.Errors, report.Total()))
+}
+
+// viewTests renders the result of a test run
+func (m Model) viewTests() string {
+	var b strings.Builder
+
+	title := TitleStyle.Render("Tests: " + m.tests.project.Name)
+	b.WriteString(title + "\n")
+
+	divider := lipgloss.NewStyle().
+		Foreground(secondaryColor).
+		Render("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	b.WriteString(divider + "\n\n")
+
+	report := m.tests.report
+	if m.tests.pending {
+		b.WriteString(FancyBoxStyle.Render(fmt.Sprintf("%s Running uv run pytest...", m.spinner.View())) + "\n\n")
+	} else if report != nil {
+		counts := []string{
+			SuccessStyle.Render(fmt.Sprintf("%d passed", report.Passed)),
+			ErrorStyle.Render(fmt.Sprintf("%d failed", report.Failed)),
+			ErrorStyle.Render(fmt.Sprintf("%d errors", report.Errors)),
+			StatusStyle.Render(fmt.Sprintf("%d skipped", report.Skipped)),
+		}
+		summary := strings.Join(counts, " • ") + "\n" +
+			StatusStyle.Render(fmt.Sprintf("%d tests in %s", report.Total(), report.Duration.Round(10*time.Millisecond)))
+		b.WriteString(FancyBoxStyle.Render(summary) + "\n\n")
+
+		if problems := report.Problems(); len(problems) > 0 {
+			b.WriteString(InputLabelStyle.Render("Failures") + "\n")
+			var rows []string
+			for i, c := range problems {
+				row := fmt.Sprintf("✗ %s (%s)", c.ID(), c.Duration.Round(time.Millisecond))
+				if i == m.tests.cursor {
+					rows = append(rows, SelectedProjectStyle.Render(" > "+row))
+				} else {
+					rows = append(rows, ProjectStyle.Render("   "+row))
+				}
+				if c.Message != "" {
+					rows = append(rows, "     "+StatusStyle.Render(truncate(c.Message, 70)))
+				}
+				if m.tests.expanded[i] {
+					rows = append(rows, indentTraceback(c.Details))
+				}
+			}
+			b.WriteString(ProjectListStyle.Render(strings.Join(rows, "\n")) + "\n\n")
+		}
+
+		if slowest := slowestCases(report.Cases, 3); len(slowest) > 0 {
+			b.WriteString(InputLabelStyle.Render("Slowest") + "\n")
+			for _, c := range slowest {
+				b.WriteString(fmt.Sprintf("%8s  %s\n", c.Duration.Round(time.Millisecond), c.ID()))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if m.error != "" {
+		b.WriteString(ErrorStyle.Render("Error: "+m.error) + "\n\n")
+	}
+
+	help := m.helpView()
+	b.WriteString(help)
+
+	return BaseStyle.Render(b.String())
+}
+
+// indentTraceback trims a traceback to fit the screen and indents it under its test
+func indentTraceback(details string) string {
+	if details == "" {
+		return "       " + StatusStyle.Render("(no traceback)")
+	}
+	// The end of a traceback is where the assertion is, so keep that part
+	lines := strings.Split(details, "\n")
+	var out []string
+	if hidden := len(lines) - maxTracebackLines; hidden > 0 {
+		out = append(out, "       "+StatusStyle.Render(fmt.Sprintf("(%d earlier lines hidden)", hidden)))
+		lines = lines[hidden:]
+	}
+	for _, line := range lines {
+		out = append(out, "       "+line)
+	}
+	return strings.Join(out, "\n")
+}
+
+// slowestCases returns the n longest-running tests that took any measurable time
+func slowestCases(cases []testrun.Case, n int) []testrun.Case {
+	sorted := slices.Clone(cases)
+	slices.SortStableFunc(sorted, func(a, b testrun.Case) int {
+		return cmp.Compare(b.Duration, a.Duration)
+	})
+	sorted = slices.DeleteFunc(sorted, func(c testrun.Case) bool {
+		return c.Duration < time.Millisecond
+	})
+	return sorted[:min(n, len(sorted))]
+}
@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/chloebubble/tuv/pkg/theme"
+)
+
+// themeState holds the theme picker's choices and where to return to
+type themeState struct {
+	names  []string
+	cursor int
+	back   AppState
+}
+
+// loadTheme applies the theme named in the config, falling back to the default on errors
+func (m *Model) loadTheme() error {
+	themes, loadErr := theme.Available(m.config.ThemeDirectory())
+	t, err := theme.Resolve(m.config.Theme, themes, lipgloss.HasDarkBackground)
+	ApplyTheme(t)
+	m.restyle()
+	if loadErr != nil {
+		return loadErr
+	}
+	return err
+}
+
+// previewTheme applies a theme by name without saving it
+func (m *Model) previewTheme(name string) {
+	themes, _ := theme.Available(m.config.ThemeDirectory())
+	t, _ := theme.Resolve(name, themes, lipgloss.HasDarkBackground)
+	ApplyTheme(t)
+	m.restyle()
+}
+
+// restyle rebuilds the styles that components keep their own copies of
+func (m *Model) restyle() {
+	m.spinner.Style = lipgloss.NewStyle().Foreground(primaryColor)
+	m.help = newHelp()
+}
+
+// openThemes shows the theme picker with the configured theme highlighted
+func (m Model) openThemes() (tea.Model, tea.Cmd) {
+	themes, err := theme.Available(m.config.ThemeDirectory())
+	if err != nil {
+		m.error = "Could not load user themes: " + err.Error()
+	}
+
+	names := []string{theme.Auto}
+	for _, t := range themes {
+		if !slices.Contains(names, t.Name) {
+			names = append(names, t.Name)
+		}
+	}
+
+	cursor := 0
+	for i, name := range names {
+		if name == m.config.Theme {
+			cursor = i
+		}
+	}
+
+	back := m.state
+	if back == StateThemes {
+		back = m.themes.back
+	}
+	m.themes = themeState{names: names, cursor: cursor, back: back}
+	m.state = StateThemes
+	return m, nil
+}
+
+// updateThemes handles updates in the theme picker state, previewing each theme as it is highlighted
+func (m Model) updateThemes(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keyMap.Up):
+			if m.themes.cursor > 0 {
+				m.themes.cursor--
+				m.previewTheme(m.themes.names[m.themes.cursor])
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Down):
+			if m.themes.cursor < len(m.themes.names)-1 {
+				m.themes.cursor++
+				m.previewTheme(m.themes.names[m.themes.cursor])
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Select):
+			previous := m.config.Theme
+			m.config.Theme = m.themes.names[m.themes.cursor]
+			if err := m.config.Save(); err != nil {
+				m.config.Theme = previous
+				m.error = "Could not save configuration: " + err.Error()
+				return m, nil
+			}
+			m.statusMsg = fmt.Sprintf("Theme set to %s", m.config.Theme)
+			m.state = m.themes.back
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Back):
+			// Undo the preview
+			m.previewTheme(m.config.Theme)
+			m.state = m.themes.back
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
+
+// viewThemes renders the theme picker
+func (m Model) viewThemes() string {
+	var b strings.Builder
+
+	title := TitleStyle.Render("Theme")
+	b.WriteString(title + "\n")
+
+	divider := lipgloss.NewStyle().
+		Foreground(secondaryColor).
+		Render("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	b.WriteString(divider + "\n\n")
+
+	var rows []string
+	for i, name := range m.themes.names {
+		label := name
+		if name == theme.Auto {
+			label = "auto (follow terminal background)"
+		}
+		if name == m.config.Theme {
+			label += " ✓"
+		}
+		if i == m.themes.cursor {
+			rows = append(rows, SelectedProjectStyle.Render(" > "+label))
+		} else {
+			rows = append(rows, ProjectStyle.Render("   "+label))
+		}
+	}
+	b.WriteString(ProjectListStyle.Render(strings.Join(rows, "\n")) + "\n\n")
+
+	// A sample of every style so the preview shows what changes
+	sample := SuccessStyle.Render("✓ success") + "  " + WarningStyle.Render("! warning") + "  " +
+		ErrorStyle.Render("✗ error") + "  " + HighlightStyle.Render("highlight") + "  " + StatusStyle.Render("status")
+	b.WriteString(FancyBoxStyle.Render(sample) + "\n\n")
+
+	if os.Getenv("NO_COLOR") != "" {
+		b.WriteString(StatusStyle.Render("NO_COLOR is set, so colors stay off whichever theme is chosen.") + "\n")
+	}
+	b.WriteString(StatusStyle.Render("Custom themes are read from "+m.config.ThemeDirectory()) + "\n\n")
+
+	if m.error != "" {
+		b.WriteString(ErrorStyle.Render("Error: "+m.error) + "\n\n")
+	}
+
+	help := m.helpView()
+	b.WriteString(help)
+
+	return BaseStyle.Render(b.String())
+}
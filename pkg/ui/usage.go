@@ -0,0 +1,208 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/chloebubble/tuv/pkg/jobs"
+	"github.com/chloebubble/tuv/pkg/scanner"
+)
+
+// usageRows is how many entries the disk usage screen shows at once
+const usageRows = 15
+
+// usageBarWidth is the width of the size bars in cells
+const usageBarWidth = 20
+
+// usageState holds the disk usage screen's navigation and results
+type usageState struct {
+	project       scanner.UVProject
+	dirs          []string
+	entries       []scanner.UsageEntry
+	packages      []scanner.UsageEntry
+	cursors       []int
+	packagesTab   bool
+	packageCursor int
+	pending       bool
+	message       string
+}
+
+// openUsage shows the disk usage breakdown for a project
+func (m Model) openUsage(project scanner.UVProject) (tea.Model, tea.Cmd) {
+	m.usage = usageState{project: project, dirs: []string{project.Path}, cursors: []int{0}}
+	m.state = StateDiskUsage
+	m.error = ""
+	m.loadUsage(project.Path, fmt.Sprintf("Measuring %s...", project.Name))
+	m.startJob("Measure packages of "+project.Name, func(ctx context.Context, report jobs.Reporter) (tea.Msg, error) {
+		packages, err := scanner.PackageUsage(project.Path)
+		if err != nil {
+			return nil, err
+		}
+		return packageUsageMsg{project: project.Path, packages: packages}, nil
+	})
+	return m, nil
+}
+
+// loadUsage measures the children of a directory in the background
+func (m *Model) loadUsage(path, message string) {
+	m.usage.pending = true
+	m.usage.message = message
+	id := m.startJob("Measure "+filepath.Base(path), func(ctx context.Context, report jobs.Reporter) (tea.Msg, error) {
+		entries, err := scanner.DirUsage(path)
+		if err != nil {
+			return nil, err
+		}
+		return dirUsageMsg{path: path, entries: entries}, nil
+	})
+	m.awaitJob(StateDiskUsage, id)
+}
+
+// updateDiskUsage handles updates in the disk usage state
+func (m Model) updateDiskUsage(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		depth := len(m.usage.dirs) - 1
+		cursor := &m.usage.cursors[depth]
+		count := len(m.usage.entries)
+		if m.usage.packagesTab {
+			cursor = &m.usage.packageCursor
+			count = len(m.usage.packages)
+		}
+
+		switch {
+		case key.Matches(msg, m.keyMap.Up):
+			if *cursor > 0 {
+				*cursor--
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Down):
+			if *cursor < count-1 {
+				*cursor++
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.NextTab):
+			m.usage.packagesTab = !m.usage.packagesTab
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Select):
+			if m.usage.pending || m.usage.packagesTab || count == 0 {
+				return m, nil
+			}
+			entry := m.usage.entries[*cursor]
+			if !entry.IsDir {
+				return m, nil
+			}
+			m.usage.dirs = append(m.usage.dirs, entry.Path)
+			m.usage.cursors = append(m.usage.cursors, 0)
+			m.loadUsage(entry.Path, fmt.Sprintf("Measuring %s...", entry.Name))
+			return m, nil
+
+		case key.Matches(msg, m.keyMap.Back):
+			if m.usage.packagesTab || depth == 0 {
+				m.state = StateProjectDetail
+				return m, nil
+			}
+			m.usage.dirs = m.usage.dirs[:depth]
+			m.usage.cursors = m.usage.cursors[:depth]
+			m.loadUsage(m.usage.dirs[depth-1], "Measuring...")
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
+
+// viewDiskUsage renders the disk usage breakdown
+func (m Model) viewDiskUsage() string {
+	var b strings.Builder
+
+	title := TitleStyle.Render("Disk Usage: " + m.usage.project.Name)
+	b.WriteString(title + "\n")
+
+	divider := lipgloss.NewStyle().
+		Foreground(secondaryColor).
+		Render("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	b.WriteString(divider + "\n\n")
+
+	dirsTab, packagesTab := HighlightStyle.Render("[Directories]"), StatusStyle.Render(" Packages ")
+	if m.usage.packagesTab {
+		dirsTab, packagesTab = StatusStyle.Render(" Directories "), HighlightStyle.Render("[Packages]")
+	}
+	b.WriteString(dirsTab + " " + packagesTab + "\n\n")
+
+	if m.usage.packagesTab {
+		if len(m.usage.packages) == 0 {
+			b.WriteString(FancyBoxStyle.Render("No installed packages found in .venv.") + "\n\n")
+		} else {
+			b.WriteString(StatusStyle.Render("Largest installed packages") + "\n")
+			b.WriteString(renderUsage(m.usage.packages, m.usage.packageCursor) + "\n\n")
+		}
+	} else {
+		current := m.usage.dirs[len(m.usage.dirs)-1]
+		rel, _ := filepath.Rel(filepath.Dir(m.usage.project.Path), current)
+		b.WriteString(StatusStyle.Render(rel) + "\n")
+
+		if m.usage.pending {
+			b.WriteString(FancyBoxStyle.Render(fmt.Sprintf("%s %s", m.spinner.View(), m.usage.message)) + "\n\n")
+		} else if len(m.usage.entries) == 0 {
+			b.WriteString(FancyBoxStyle.Render("This directory is empty.") + "\n\n")
+		} else {
+			b.WriteString(renderUsage(m.usage.entries, m.usage.cursors[len(m.usage.cursors)-1]) + "\n\n")
+		}
+	}
+
+	if m.error != "" {
+		b.WriteString(ErrorStyle.Render("Error: "+m.error) + "\n\n")
+	}
+
+	help := m.helpView()
+	b.WriteString(help)
+
+	return BaseStyle.Render(b.String())
+}
+
+// renderUsage draws entries as horizontal bars scaled to their share of the total, scrolled to keep the cursor visible
+func renderUsage(entries []scanner.UsageEntry, cursor int) string {
+	var total int64
+	for _, entry := range entries {
+		total += entry.Size
+	}
+
+	start := 0
+	if cursor >= usageRows {
+		start = cursor - usageRows + 1
+	}
+	end := min(start+usageRows, len(entries))
+
+	barStyle := lipgloss.NewStyle().Foreground(accentColor)
+	var rows []string
+	for i := start; i < end; i++ {
+		entry := entries[i]
+		filled := 0
+		if total > 0 {
+			filled = int(float64(entry.Size) / float64(total) * usageBarWidth)
+		}
+		bar := barStyle.Render(strings.Repeat("█", filled)) + strings.Repeat("░", usageBarWidth-filled)
+
+		name := entry.Name
+		if entry.IsDir {
+			name += "/"
+		}
+		row := fmt.Sprintf("%-22s %s %10s", truncate(name, 22), bar, scanner.FormatSize(entry.Size))
+		if i == cursor {
+			rows = append(rows, SelectedProjectStyle.Render(" > "+row))
+		} else {
+			rows = append(rows, ProjectStyle.Render("   "+row))
+		}
+	}
+
+	footer := fmt.Sprintf("%d of %d • total %s", end-start, len(entries), scanner.FormatSize(total))
+	return ProjectListStyle.Render(strings.Join(rows, "\n") + "\n\n" + StatusStyle.Render(footer))
+}
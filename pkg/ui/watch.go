@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/chloebubble/tuv/pkg/scanner"
+	"github.com/chloebubble/tuv/pkg/watcher"
+)
+
+// watchDebounce is how long the filesystem must be quiet before changes are applied
+const watchDebounce = 500 * time.Millisecond
+
+// startWatcher (re)starts watching the parent directory when watching is enabled
+func (m *Model) startWatcher() tea.Cmd {
+	if m.watcher != nil {
+		m.watcher.Close()
+		m.watcher = nil
+	}
+	if !m.config.Watch {
+		return nil
+	}
+
+	w, err := watcher.New(m.config.ParentDirectory, watchDebounce)
+	if err != nil {
+		m.statusMsg = "Live updates unavailable: " + err.Error()
+		return nil
+	}
+	m.watcher = w
+	return waitForChanges(w)
+}
+
+// waitForChanges waits for the next batch of changed projects and rescans just those
+func waitForChanges(w *watcher.Watcher) tea.Cmd {
+	return func() tea.Msg {
+		paths, ok := <-w.Changes()
+		if !ok {
+			return nil
+		}
+
+		msg := projectsChangedMsg{watcher: w}
+		for _, path := range paths {
+			if project, ok := scanner.ScanProject(path); ok {
+				msg.updated = append(msg.updated, project)
+			} else {
+				msg.removed = append(msg.removed, path)
+			}
+		}
+		return msg
+	}
+}
+
+// applyProjectChanges merges incremental scan results into the project list
+func (m Model) applyProjectChanges(msg projectsChangedMsg) (tea.Model, tea.Cmd) {
+	// Drop batches from a watcher that has since been replaced
+	if msg.watcher != m.watcher {
+		return m, nil
+	}
+
+	projects := slices.Clone(m.projects)
+	for _, path := range msg.removed {
+		projects = slices.DeleteFunc(projects, func(p scanner.UVProject) bool {
+			return p.Path == path
+		})
+	}
+
+	for _, project := range msg.updated {
+		i := slices.IndexFunc(projects, func(p scanner.UVProject) bool {
+			return p.Path == project.Path
+		})
+		if i >= 0 {
+			projects[i] = project
+			continue
+		}
+		// Keep the list in the same name order as a full scan
+		at, _ := slices.BinarySearchFunc(projects, project.Name, func(p scanner.UVProject, name string) int {
+			return strings.Compare(p.Name, name)
+		})
+		projects = slices.Insert(projects, at, project)
+	}
+
+	// Keep the cursor on the same project where possible
+	current, _ := m.currentProject()
+	m.projects = projects
+	m.selectedProject = max(slices.IndexFunc(m.visibleProjects(), func(p scanner.UVProject) bool {
+		return p.Path == current.Path
+	}), 0)
+	if m.selectedProject >= len(m.visibleProjects()) {
+		m.selectedProject = max(len(m.visibleProjects())-1, 0)
+	}
+	m.pruneSelection()
+
+	// The project being viewed may have gone away
+	if now, ok := m.currentProject(); m.state == StateProjectDetail && (!ok || now.Path != current.Path) {
+		m.state = StateProjectList
+	}
+	if len(m.projects) == 0 && m.state == StateProjectList {
+		m.state = StateMainMenu
+	}
+
+	return m, waitForChanges(m.watcher)
+}
@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/chloebubble/tuv/pkg/scanner"
+)
+
+// allProjects returns every project including workspace members, each member following its root
+func (m Model) allProjects() []scanner.UVProject {
+	var projects []scanner.UVProject
+	for _, project := range m.projects {
+		projects = append(projects, project)
+		projects = append(projects, project.Members...)
+	}
+	return projects
+}
+
+// toggleWorkspace expands or collapses the highlighted workspace. Collapsing from a member moves
+// the cursor back to its root.
+func (m Model) toggleWorkspace(expand bool) Model {
+	project, ok := m.currentProject()
+	if !ok || project.Workspace == "" {
+		return m
+	}
+	root := project.Workspace
+	if expand && !project.IsWorkspaceRoot() {
+		return m
+	}
+
+	if expand {
+		m.expanded[root] = true
+		return m
+	}
+	delete(m.expanded, root)
+	for i, p := range m.visibleProjects() {
+		if p.Path == root {
+			m.selectedProject = i
+		}
+	}
+	return m
+}
+
+// canExpand reports whether the highlighted project is a collapsed workspace
+func (m Model) canExpand() bool {
+	project, ok := m.currentProject()
+	return ok && project.IsWorkspaceRoot() && !m.expanded[project.Path]
+}
+
+// canCollapse reports whether the highlighted project is in an expanded workspace
+func (m Model) canCollapse() bool {
+	project, ok := m.currentProject()
+	return ok && project.Workspace != "" && m.expanded[project.Workspace]
+}
+
+// workspaceBadge renders the marker shown next to a workspace root in the project list
+func (m Model) workspaceBadge(project scanner.UVProject) string {
+	badge := fmt.Sprintf("workspace, %d members", len(project.Members))
+	if project.HasLock {
+		badge += ", shared lock"
+	}
+	return HighlightStyle.Render("[" + badge + "]")
+}
+
+// expandMarker returns the arrow in front of a workspace root showing whether it is expanded
+func (m Model) expandMarker(project scanner.UVProject) string {
+	switch {
+	case !project.IsWorkspaceRoot():
+		return ""
+	case m.expanded[project.Path]:
+		return "▾ "
+	default:
+		return "▸ "
+	}
+}
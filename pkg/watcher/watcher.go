@@ -0,0 +1,171 @@
+package watcher
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// projectFiles are the names inside a project directory whose changes affect how it is detected
+var projectFiles = map[string]bool{
+	"pyproject.toml":  true,
+	"uv.lock":         true,
+	".python-version": true,
+	".venv":           true,
+}
+
+// Watcher watches a parent directory and the projects inside it, reporting which
+// project directories changed once events have settled for the debounce interval
+type Watcher struct {
+	root     string
+	debounce time.Duration
+	fs       *fsnotify.Watcher
+	changes  chan []string
+	// done is closed by Close so a flush waiting for a reader gives up
+	done      chan struct{}
+	closeOnce sync.Once
+
+	mu      sync.Mutex
+	pending map[string]bool
+	timer   *time.Timer
+	closed  bool
+	// flushes counts scheduled and running flushes, which Close waits for before closing changes
+	flushes sync.WaitGroup
+}
+
+// New starts watching root and each directory directly inside it
+func New(root string, debounce time.Duration) (*Watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := fsw.Add(root); err != nil {
+		fsw.Close()
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		fsw.Close()
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			// Unwatchable projects still show up through the root watch
+			fsw.Add(filepath.Join(root, entry.Name()))
+		}
+	}
+
+	w := &Watcher{
+		root:     root,
+		debounce: debounce,
+		fs:       fsw,
+		changes:  make(chan []string),
+		done:     make(chan struct{}),
+		pending:  make(map[string]bool),
+	}
+	go w.run()
+	return w, nil
+}
+
+// Changes delivers batches of project directories that were added, removed or modified. It is
+// closed once the watcher is.
+func (w *Watcher) Changes() <-chan []string {
+	return w.changes
+}
+
+// Close stops watching and closes the Changes channel, dropping changes not yet delivered
+func (w *Watcher) Close() error {
+	var err error
+	w.closeOnce.Do(func() {
+		close(w.done)
+		err = w.fs.Close()
+
+		w.mu.Lock()
+		w.closed = true
+		if w.timer != nil && w.timer.Stop() {
+			w.flushes.Done()
+		}
+		w.mu.Unlock()
+
+		w.flushes.Wait()
+		close(w.changes)
+	})
+	return err
+}
+
+// run turns raw filesystem events into pending project paths
+func (w *Watcher) run() {
+	for {
+		select {
+		case event, ok := <-w.fs.Events:
+			if !ok {
+				return
+			}
+			w.handle(event)
+		case _, ok := <-w.fs.Errors:
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+// handle maps an event to the project it affects
+func (w *Watcher) handle(event fsnotify.Event) {
+	dir := filepath.Dir(event.Name)
+
+	switch {
+	case dir == w.root:
+		// A project directory appeared, disappeared or was renamed
+		if event.Has(fsnotify.Create) {
+			if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+				w.fs.Add(event.Name)
+			}
+		}
+		w.queue(event.Name)
+
+	case filepath.Dir(dir) == w.root && projectFiles[filepath.Base(event.Name)]:
+		w.queue(dir)
+	}
+}
+
+// queue records a changed project and restarts the debounce timer
+func (w *Watcher) queue(projectPath string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return
+	}
+
+	w.pending[projectPath] = true
+	if w.timer != nil && w.timer.Stop() {
+		w.flushes.Done()
+	}
+	w.flushes.Add(1)
+	w.timer = time.AfterFunc(w.debounce, func() {
+		defer w.flushes.Done()
+		w.flush()
+	})
+}
+
+// flush sends the pending project paths as one batch
+func (w *Watcher) flush() {
+	w.mu.Lock()
+	paths := make([]string, 0, len(w.pending))
+	for path := range w.pending {
+		paths = append(paths, path)
+	}
+	w.pending = make(map[string]bool)
+	w.mu.Unlock()
+
+	if len(paths) > 0 {
+		select {
+		case w.changes <- paths:
+		case <-w.done:
+		}
+	}
+}